@@ -2,8 +2,14 @@ package main
 
 import (
 	"fmt"
+	"github.com/KOU050223/flasgo/internal/db"
+	"github.com/KOU050223/flasgo/internal/doctor"
 	"github.com/KOU050223/flasgo/internal/filemaker"
 	"github.com/KOU050223/flasgo/internal/help"
+	"github.com/KOU050223/flasgo/internal/python"
+	"github.com/KOU050223/flasgo/internal/runner"
+	"github.com/KOU050223/flasgo/internal/scaffold"
+	"github.com/KOU050223/flasgo/internal/venv"
 	"os"
 )
 
@@ -16,18 +22,41 @@ func main() {
 		return
 	}
 
+	var err error
 	switch args[0] {
 	case "create":
-		// プロジェクト名が指定されている場合は非対話モード
-		if len(args) > 1 {
-			filemaker.GenerateWithDefaults(args[1])
-		} else {
-			filemaker.Generator()
-		}
+		// プロジェクト名が指定されていれば非対話モード、なければ対話モード
+		err = filemaker.Create(args[1:])
+	case "add":
+		err = filemaker.Add(args[1:])
+	case "python":
+		err = python.Run(args[1:])
+	case "venv":
+		err = venv.Run(args[1:])
+	case "run":
+		err = runner.Run(args[1:])
+	case "db":
+		err = db.Run(args[1:])
+	case "make:model":
+		err = scaffold.Model(args[1:])
+	case "make:seeder":
+		err = scaffold.Seeder(args[1:])
+	case "db:seed":
+		err = db.Seed(args[1:])
+	case "db:load":
+		err = db.Load(args[1:])
+	case "doctor":
+		err = doctor.Run(args[1:])
 	case "help":
 		help.Help()
 	default:
 		fmt.Printf("不明なコマンド: %s\n", args[0])
 		help.Help()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Printf("❌ エラー: %v\n", err)
+		os.Exit(1)
 	}
 }
@@ -1,3 +1,5 @@
 module github.com/KOU050223/flasgo
 
 go 1.23.5
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,19 @@
+package cli
+
+import "flag"
+
+// オプションを解析して位置引数を返す（オプションと位置引数の順序は問わない）
+// 標準の flag パッケージは最初の位置引数で解析を止めるため、残りを繰り返し解析する
+func ParseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,275 @@
+package db
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/cli"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/runner"
+)
+
+// Flask-Migrate がマイグレーションを置くディレクトリ
+const migrationsDir = "migrations"
+
+// db のサブコマンドと、省略時に flask db に渡すリビジョン
+var subcommands = []struct {
+	name            string
+	defaultRevision string // リビジョンを受け取るサブコマンドの省略時の値（受け取らない場合は空文字）
+}{
+	{name: "init"},
+	{name: "migrate"},
+	{name: "upgrade", defaultRevision: "head"},
+	{name: "downgrade", defaultRevision: "-1"},
+	{name: "history"},
+}
+
+// db コマンド: プロジェクトの仮想環境で flask db（Flask-Migrate）を実行する
+//
+//	flasgo db init
+//	flasgo db migrate -m "message"
+//	flasgo db upgrade [REVISION]
+//	flasgo db downgrade [REVISION]
+//	flasgo db history
+func Run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("サブコマンドを指定してください (%s)", subcommandNames())
+	}
+	name := args[0]
+	defaultRevision, ok := "", false
+	for _, sub := range subcommands {
+		if sub.name == name {
+			defaultRevision, ok = sub.defaultRevision, true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("不明なサブコマンド: %s (%s から選択してください)", name, subcommandNames())
+	}
+
+	inv, err := parseArgs(name, defaultRevision, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	project, err := openProject(inv.dir, inv.venv)
+	if err != nil {
+		return err
+	}
+	_, statErr := os.Stat(filepath.Join(project.Root, migrationsDir))
+	if name == "init" && statErr == nil {
+		return fmt.Errorf("%s/ は既に存在します", migrationsDir)
+	}
+	if name != "init" && os.IsNotExist(statErr) {
+		return fmt.Errorf("%s/ がありません。先に 'flasgo db init' を実行してください", migrationsDir)
+	}
+
+	return runFlask(project, "db "+name, inv.flaskArgs...)
+}
+
+// 負のリビジョン（-1 など）
+var negativeRevisionPattern = regexp.MustCompile(`^-\d+$`)
+
+// db のサブコマンドの引数を解析した結果
+type invocation struct {
+	dir       string   // プロジェクトのディレクトリ
+	venv      string   // --venv で指定された仮想環境
+	flaskArgs []string // flask に渡す引数
+}
+
+// サブコマンドの引数を解析して flask db に渡す引数を求める
+func parseArgs(name, defaultRevision string, args []string) (*invocation, error) {
+	fs := flag.NewFlagSet("db "+name, flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	venvPath := fs.String("venv", "", "仮想環境のパス（省略時は venv/ または .venv/）")
+	var message *string
+	if name == "migrate" {
+		message = fs.String("m", "", "マイグレーションのメッセージ")
+	}
+
+	// リビジョンを受け取るサブコマンドでは、負のリビジョンをオプションとして解析しない
+	// （"flasgo db downgrade -2" の -2 は位置引数）
+	var revisions []string
+	if defaultRevision != "" {
+		var rest []string
+		for _, arg := range args {
+			if negativeRevisionPattern.MatchString(arg) {
+				revisions = append(revisions, arg)
+			} else {
+				rest = append(rest, arg)
+			}
+		}
+		args = rest
+	}
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	positional = append(revisions, positional...)
+
+	// flask db に渡す引数
+	flaskArgs := []string{"db", name}
+	switch {
+	case defaultRevision != "" && len(positional) <= 1:
+		// 負のリビジョンがオプションとして解釈されないように "--" の後に渡す
+		if len(positional) == 0 {
+			positional = []string{defaultRevision}
+		}
+		flaskArgs = append(flaskArgs, "--")
+		flaskArgs = append(flaskArgs, positional...)
+	case len(positional) > 0:
+		return nil, fmt.Errorf("引数が多すぎます: %s", strings.Join(positional, " "))
+	case message != nil:
+		if *message == "" {
+			return nil, fmt.Errorf("-m でマイグレーションのメッセージを指定してください")
+		}
+		flaskArgs = append(flaskArgs, "-m", *message)
+	}
+
+	return &invocation{dir: *dir, venv: *venvPath, flaskArgs: flaskArgs}, nil
+}
+
+// データベース機能を使うプロジェクトを開く（venvPath は --venv で指定された仮想環境）
+func openProject(dir, venvPath string) (*runner.Project, error) {
+	project, err := runner.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	project.Venv = venvPath
+	if err := project.RequireFeature("database"); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// プロジェクトの仮想環境で flask コマンドを実行し、開始と終了を表示する
+func runFlask(project *runner.Project, label string, args ...string) error {
+	cmd, err := project.Flask(args...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🗃️  flask %s を実行中...\n", strings.Join(args, " "))
+	if err := runner.Exec(cmd); err != nil {
+		return fmt.Errorf("%s に失敗しました: %v", label, err)
+	}
+	fmt.Printf("✅ %s が完了しました\n", label)
+	return nil
+}
+
+// サブコマンド名の一覧（カンマ区切り）
+func subcommandNames() string {
+	names := make([]string, len(subcommands))
+	for i, sub := range subcommands {
+		names[i] = sub.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// シーダーのパッケージ（flasgo make:seeder で作成される）
+const seedersDir = "seeders"
+
+// db:seed コマンド: プロジェクトの仮想環境で flask seed を実行する
+//
+//	flasgo db:seed [NAME...] [--truncate]
+func Seed(args []string) error {
+	fs := flag.NewFlagSet("db:seed", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	venvPath := fs.String("venv", "", "仮想環境のパス（省略時は venv/ または .venv/）")
+	truncate := fs.Bool("truncate", false, "投入前に各シーダーのモデルのデータを削除する")
+
+	names, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	project, err := openProject(*dir, *venvPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(project.Root, seedersDir, "__init__.py")); os.IsNotExist(err) {
+		return fmt.Errorf("%s/ がありません。先に 'flasgo make:seeder <Name>' でシーダーを作成してください", seedersDir)
+	}
+
+	flaskArgs := append([]string{"seed"}, names...)
+	if *truncate {
+		flaskArgs = append(flaskArgs, "--truncate")
+	}
+	return runFlask(project, "db:seed", flaskArgs...)
+}
+
+// db:load で読み込めるフィクスチャの拡張子
+var fixtureExts = []string{".csv", ".json", ".yaml", ".yml"}
+
+// db:load コマンド: プロジェクトの仮想環境で flask load を実行し、フィクスチャのファイルをモデルに投入する
+//
+//	flasgo db:load FILE --model MODEL [--truncate]
+func Load(args []string) error {
+	fs := flag.NewFlagSet("db:load", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	venvPath := fs.String("venv", "", "仮想環境のパス（省略時は venv/ または .venv/）")
+	model := fs.String("model", "", "投入先のモデル（例: User）")
+	truncate := fs.Bool("truncate", false, "投入前にモデルのデータを削除する")
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("フィクスチャのファイルを1つ指定してください (例: flasgo db:load fixtures/users.csv --model User)")
+	}
+	if *model == "" {
+		return fmt.Errorf("--model で投入先のモデルを指定してください")
+	}
+
+	// flask はプロジェクトのディレクトリで実行するため絶対パスにする
+	file, err := filepath.Abs(positional[0])
+	if err != nil {
+		return fmt.Errorf("パスの解決に失敗: %v", err)
+	}
+	if !isFixture(file) {
+		return fmt.Errorf("対応していない形式です: %s (%s)", positional[0], strings.Join(fixtureExts, ", "))
+	}
+	if info, err := os.Stat(file); err != nil || info.IsDir() {
+		return fmt.Errorf("ファイルが見つかりません: %s", positional[0])
+	}
+
+	project, err := openProject(*dir, *venvPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(project.Root, seedersDir, "fixtures.py")); os.IsNotExist(err) {
+		return fmt.Errorf("%s/fixtures.py がありません。'flasgo make:seeder <Name>' で %s/ を作成してください", seedersDir, seedersDir)
+	}
+
+	flaskArgs := []string{"load", file, "--model", naming.ClassName(*model)}
+	if *truncate {
+		flaskArgs = append(flaskArgs, "--truncate")
+	}
+	return runFlask(project, "db:load", flaskArgs...)
+}
+
+// 読み込める形式のフィクスチャか（拡張子で判定する）
+func isFixture(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, fixtureExt := range fixtureExts {
+		if ext == fixtureExt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantDir string
+		wantErr string
+	}{
+		{name: "downgrade", want: []string{"db", "downgrade", "--", "-1"}},
+		{name: "downgrade", args: []string{"-2"}, want: []string{"db", "downgrade", "--", "-2"}},
+		{name: "downgrade", args: []string{"--", "-2"}, want: []string{"db", "downgrade", "--", "-2"}},
+		{name: "downgrade", args: []string{"-3", "--dir", "app"}, want: []string{"db", "downgrade", "--", "-3"}, wantDir: "app"},
+		{name: "downgrade", args: []string{"base"}, want: []string{"db", "downgrade", "--", "base"}},
+		{name: "downgrade", args: []string{"-1", "-2"}, wantErr: "引数が多すぎます"},
+		{name: "upgrade", want: []string{"db", "upgrade", "--", "head"}},
+		{name: "upgrade", args: []string{"ae1027a6acf"}, want: []string{"db", "upgrade", "--", "ae1027a6acf"}},
+		{name: "migrate", args: []string{"-m", "add users"}, want: []string{"db", "migrate", "-m", "add users"}},
+		{name: "migrate", wantErr: "-m でマイグレーションのメッセージを指定してください"},
+		{name: "history", args: []string{"-1"}, wantErr: "flag provided but not defined"},
+		{name: "init", args: []string{"extra"}, wantErr: "引数が多すぎます"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			defaultRevision := ""
+			for _, sub := range subcommands {
+				if sub.name == tt.name {
+					defaultRevision = sub.defaultRevision
+				}
+			}
+
+			inv, err := parseArgs(tt.name, defaultRevision, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(inv.flaskArgs, tt.want) {
+				t.Errorf("flaskArgs = %q, want %q", inv.flaskArgs, tt.want)
+			}
+			wantDir := tt.wantDir
+			if wantDir == "" {
+				wantDir = "."
+			}
+			if inv.dir != wantDir {
+				t.Errorf("dir = %q, want %q", inv.dir, wantDir)
+			}
+		})
+	}
+}
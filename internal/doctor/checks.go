@@ -0,0 +1,246 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/python"
+	"github.com/KOU050223/flasgo/internal/runner"
+)
+
+// .env の SECRET_KEY のプレースホルダー（features/env/dotenv/dot_env.tmpl で生成される値）
+const secretKeyPlaceholder = "your-secret-key-here"
+
+// Python のバージョン（仮想環境があればその Python、なければ見つかったインタープリタ）
+func checkPython(project *runner.Project) []result {
+	r := result{name: "Python"}
+	pin, err := python.ReadVersionFile(project.Root)
+	if err != nil {
+		r.status, r.message = fail, err.Error()
+		return []result{r}
+	}
+	minimum := python.Minimum(pin)
+
+	var interpreter *python.Interpreter
+	if executable, err := project.Python(); err == nil {
+		interpreter, err = python.Inspect(executable)
+		if err != nil {
+			r.status, r.message = fail, err.Error()
+			return []result{r}
+		}
+	} else if interpreter, err = python.Find(minimum); err != nil {
+		r.status, r.message = fail, err.Error()
+		r.hint = fmt.Sprintf("Python %s 以上をインストールしてください", minimum)
+		return []result{r}
+	}
+
+	if python.Compare(interpreter.Version, minimum) < 0 {
+		r.status = fail
+		r.message = fmt.Sprintf("%s (%s) は Python %s 未満です", interpreter.Version, interpreter.Path, minimum)
+		r.hint = fmt.Sprintf("仮想環境を削除して 'flasgo venv --python %s' で作り直してください", minimum)
+		return []result{r}
+	}
+	r.message = fmt.Sprintf("%s (%s)", interpreter.Version, interpreter.Path)
+	return []result{r}
+}
+
+// 仮想環境があるか
+func checkVenv(project *runner.Project) []result {
+	r := result{name: "仮想環境"}
+	path, err := project.VenvDir()
+	switch {
+	case err == nil:
+		r.message = path
+	case project.Managed():
+		r.status = warn
+		r.message = fmt.Sprintf("%s が管理する仮想環境はプロジェクト内に見つかりません", project.Packaging)
+		r.hint = fmt.Sprintf("'%s run flask --version' で確認してください", project.Packaging)
+	default:
+		r.status, r.message = fail, "見つかりません"
+		r.hint = "'flasgo venv' で作成してください"
+	}
+	return []result{r}
+}
+
+// インストール済みのパッケージが requirements.txt を満たしているか
+func checkRequirements(project *runner.Project) []result {
+	r := result{name: "依存関係"}
+	requirements, err := parseRequirementsFile(filepath.Join(project.Root, "requirements.txt"))
+	if os.IsNotExist(err) {
+		r.status, r.message = warn, "requirements.txt がないため確認できません"
+		if project.Packaging == "pip-tools" {
+			r.hint = "'pip-compile -o requirements.txt pyproject.toml' で生成してください"
+		}
+		return []result{r}
+	}
+	if err != nil {
+		r.status, r.message = fail, err.Error()
+		return []result{r}
+	}
+
+	venvDir, err := project.VenvDir()
+	if err != nil {
+		r.status, r.message = warn, "仮想環境がないため確認できません"
+		return []result{r}
+	}
+	installed, err := installedPackages(venvDir)
+	if err != nil {
+		r.status, r.message = fail, err.Error()
+		return []result{r}
+	}
+
+	var problems []string
+	for _, requirement := range requirements {
+		version, ok := installed[normalizeName(requirement.Name)]
+		if !ok {
+			problems = append(problems, requirement.Name+" (未インストール)")
+		} else if !requirement.SatisfiedBy(version) {
+			problems = append(problems, fmt.Sprintf("%s %s (%s が必要)", requirement.Name, version, requirement.Specifier()))
+		}
+	}
+	if len(problems) > 0 {
+		r.status = fail
+		r.message = strings.Join(problems, ", ")
+		r.hint = "'flasgo venv' で依存関係をインストールしてください"
+		return []result{r}
+	}
+	r.message = fmt.Sprintf("requirements.txt の %d 個のパッケージを満たしています", len(requirements))
+	return []result{r}
+}
+
+// Python のコードが参照する環境変数（os.environ.get('X') / os.getenv('X') / os.environ['X']）
+var envReferencePattern = regexp.MustCompile(`os\.(?:environ\.get|getenv)\(\s*['"](\w+)['"]|os\.environ\[\s*['"](\w+)['"]\s*\]`)
+
+// 調べないディレクトリ（仮想環境など）
+var skippedDirs = map[string]bool{
+	"venv":         true,
+	".venv":        true,
+	".git":         true,
+	"__pycache__":  true,
+	"node_modules": true,
+}
+
+// コードが参照する環境変数が .env に定義されているか
+func checkEnv(project *runner.Project) []result {
+	r := result{name: ".env"}
+	referenced, err := referencedEnvVars(project.Root)
+	if err != nil {
+		r.status, r.message = fail, err.Error()
+		return []result{r}
+	}
+	if len(referenced) == 0 {
+		r.message = "コードは環境変数を参照していません"
+		return []result{r}
+	}
+
+	defined, err := dotenvValues(project)
+	if err != nil {
+		r.status, r.message = fail, err.Error()
+		return []result{r}
+	}
+	if defined == nil {
+		r.status = fail
+		r.message = fmt.Sprintf(".env がありません（参照している変数: %s）", strings.Join(referenced, ", "))
+		r.hint = "'flasgo add env' で作成してください"
+		return []result{r}
+	}
+
+	var missing []string
+	for _, name := range referenced {
+		if _, ok := defined[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		r.status = fail
+		r.message = "未定義の変数: " + strings.Join(missing, ", ")
+		r.hint = ".env に追加してください"
+		return []result{r}
+	}
+	r.message = fmt.Sprintf("参照している変数 (%s) が全て定義されています", strings.Join(referenced, ", "))
+	return []result{r}
+}
+
+// SECRET_KEY が生成時のプレースホルダーのままでないか
+func checkSecretKey(project *runner.Project) []result {
+	defined, err := dotenvValues(project)
+	if err != nil || defined == nil {
+		return nil
+	}
+	value, ok := defined["SECRET_KEY"]
+	if !ok {
+		return nil
+	}
+
+	r := result{name: "SECRET_KEY"}
+	switch value {
+	case secretKeyPlaceholder:
+		r.status, r.message = warn, "生成時のプレースホルダーのままです"
+		r.hint = "ランダムな値に変更してください（例: python -c \"import secrets; print(secrets.token_hex(32))\"）"
+	case "":
+		r.status, r.message = fail, "空です"
+		r.hint = ".env に値を設定してください"
+	default:
+		r.message = "設定されています"
+	}
+	return []result{r}
+}
+
+// プロジェクトの Python ファイルが参照する環境変数（名前順）
+func referencedEnvVars(root string) ([]string, error) {
+	found := make(map[string]bool)
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".py" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range envReferencePattern.FindAllStringSubmatch(string(content), -1) {
+			found[m[1]+m[2]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ソースコードの読み込みに失敗: %v", err)
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// .env の変数（.env がなければ nil）
+func dotenvValues(project *runner.Project) (map[string]string, error) {
+	path := filepath.Join(project.Root, ".env")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	entries, err := runner.LoadDotenv(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, _ := strings.Cut(entry, "=")
+		values[key] = value
+	}
+	return values, nil
+}
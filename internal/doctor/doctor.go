@@ -0,0 +1,91 @@
+package doctor
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/runner"
+)
+
+// 診断結果の種類
+type status int
+
+const (
+	pass status = iota
+	warn
+	fail
+)
+
+// 結果の表示に使う記号
+var statusMarks = map[status]string{
+	pass: "✅",
+	warn: "⚠️ ",
+	fail: "❌",
+}
+
+// 1つの診断項目の結果
+type result struct {
+	status  status
+	name    string // 項目名
+	message string // 詳細
+	hint    string // 問題がある場合の対処方法
+}
+
+// 診断項目（project を調べて結果を返す）
+type check func(project *runner.Project) []result
+
+// 診断項目（表示順）
+var checks = []check{
+	checkPython,
+	checkVenv,
+	checkRequirements,
+	checkEnv,
+	checkSecretKey,
+}
+
+// doctor コマンド: プロジェクトの環境を診断して結果を表示する
+// 失敗した項目があればエラーを返す（終了コードが 0 以外になる）
+//
+//	flasgo doctor [--dir DIR] [--venv PATH]
+func Run(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	venvPath := fs.String("venv", "", "仮想環境のパス（省略時は venv/ または .venv/）")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("引数が多すぎます: %s", strings.Join(fs.Args(), " "))
+	}
+
+	project, err := runner.Load(*dir)
+	if err != nil {
+		return err
+	}
+	project.Venv = *venvPath
+
+	fmt.Printf("🩺 プロジェクトを診断します: %s\n\n", project.Root)
+
+	counts := make(map[status]int)
+	for _, check := range checks {
+		for _, r := range check(project) {
+			counts[r.status]++
+			fmt.Printf("  %s %s: %s\n", statusMarks[r.status], r.name, r.message)
+			if r.status != pass && r.hint != "" {
+				fmt.Printf("       → %s\n", r.hint)
+			}
+		}
+	}
+
+	fmt.Printf("\n結果: 成功 %d / 警告 %d / 失敗 %d\n", counts[pass], counts[warn], counts[fail])
+	if counts[fail] > 0 {
+		return fmt.Errorf("%d 件の問題が見つかりました", counts[fail])
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package doctor
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// requirements.txt の1行分の要件
+type requirement struct {
+	Name       string
+	Specifiers []specifier
+}
+
+// バージョン指定（例: >=2.3.0）
+type specifier struct {
+	Op      string
+	Version string
+}
+
+var (
+	// パッケージ名・extras・バージョン指定
+	requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+	// バージョン指定の1項目
+	specifierPattern = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*(\S+)$`)
+	// バージョンのリリース番号の部分（例: 2.3.0rc1 の 2.3.0）
+	releasePattern = regexp.MustCompile(`^\d+(?:\.\d+)*`)
+	// リリース番号の後ろのプレリリース・ポストリリース・開発版・ローカルバージョン（PEP 440）
+	versionSuffixPattern = regexp.MustCompile(`^(?:[-_.]?(alpha|a|beta|b|rc|c|preview|pre)[-_.]?(\d*))?(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?(?:[-_.]?(dev)[-_.]?(\d*))?(?:\+.*)?$`)
+	// パッケージ名の正規化（PEP 503）
+	nameSeparatorPattern = regexp.MustCompile(`[-_.]+`)
+)
+
+// requirements.txt を解析する
+// オプション行 (-r, --index-url など)、コメント、環境マーカー (; 以降) は無視する
+func parseRequirementsFile(path string) ([]requirement, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("requirements.txt の読み込みに失敗: %v", err)
+	}
+
+	var requirements []requirement
+	for i, line := range strings.Split(string(content), "\n") {
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		if j := strings.Index(line, ";"); j >= 0 {
+			line = line[:j]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		m := requirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("requirements.txt:%d: 解析できない行です: %s", i+1, line)
+		}
+		r := requirement{Name: m[1]}
+		spec := strings.TrimSpace(m[2])
+		if strings.HasPrefix(spec, "@") { // URL 指定はバージョンを確認しない
+			spec = ""
+		}
+		for _, item := range strings.Split(spec, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			s := specifierPattern.FindStringSubmatch(item)
+			if s == nil {
+				return nil, fmt.Errorf("requirements.txt:%d: 不正なバージョン指定です: %s", i+1, item)
+			}
+			r.Specifiers = append(r.Specifiers, specifier{Op: s[1], Version: s[2]})
+		}
+		requirements = append(requirements, r)
+	}
+	return requirements, nil
+}
+
+// バージョン指定の表示用の文字列
+func (r requirement) Specifier() string {
+	items := make([]string, len(r.Specifiers))
+	for i, s := range r.Specifiers {
+		items[i] = s.Op + s.Version
+	}
+	return strings.Join(items, ",")
+}
+
+// インストールされているバージョンが要件を満たすか
+func (r requirement) SatisfiedBy(version string) bool {
+	for _, s := range r.Specifiers {
+		if !s.matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// バージョンが指定を満たすか（順序は PEP 440 に従い、X.* はリリース番号の部分だけを比較する）
+func (s specifier) matches(version string) bool {
+	if s.Op == "===" {
+		return version == s.Version
+	}
+	if strings.HasSuffix(s.Version, ".*") && (s.Op == "==" || s.Op == "!=") {
+		prefix := releaseNumbers(strings.TrimSuffix(s.Version, ".*"))
+		matched := hasPrefix(releaseNumbers(version), prefix)
+		return matched == (s.Op == "==")
+	}
+
+	c := compareVersions(version, s.Version)
+	switch s.Op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<=":
+		return c <= 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case ">":
+		return c > 0
+	case "~=": // ~=X.Y は >=X.Y かつ X.* と同じ
+		release := releaseNumbers(s.Version)
+		if len(release) < 2 {
+			return c >= 0
+		}
+		return c >= 0 && hasPrefix(releaseNumbers(version), release[:len(release)-1])
+	}
+	return false
+}
+
+// バージョンのリリース番号（"2.3.0rc1" → [2 3 0]）
+func releaseNumbers(version string) []int {
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		digits := part
+		for i, c := range part {
+			if c < '0' || c > '9' {
+				digits = part[:i]
+				break
+			}
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		if digits != part {
+			break
+		}
+	}
+	return numbers
+}
+
+// バージョンの順序を決める要素（PEP 440）
+// 同じリリース番号では 開発版 < プレリリース < 正式版 < ポストリリース の順になる
+type versionKey struct {
+	release []int
+	pre     [2]int // プレリリースの段階 (a=0, b=1, rc=2) と番号（正式版は {3, 0}、開発版だけの場合は {-1, 0}）
+	post    int    // ポストリリースの番号（なければ -1）
+	dev     int    // 開発版の番号（なければ math.MaxInt）
+}
+
+// プレリリースの段階（別名を含む）
+var preReleasePhases = map[string]int{
+	"a": 0, "alpha": 0,
+	"b": 1, "beta": 1,
+	"c": 2, "rc": 2, "pre": 2, "preview": 2,
+}
+
+// バージョンを比較用に分解する（解析できない部分は無視する）
+func parseVersion(version string) versionKey {
+	version = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+	release := releasePattern.FindString(version)
+	key := versionKey{release: releaseNumbers(release), pre: [2]int{3, 0}, post: -1, dev: math.MaxInt}
+
+	m := versionSuffixPattern.FindStringSubmatch(version[len(release):])
+	if m == nil {
+		return key
+	}
+	number := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	if m[1] != "" {
+		key.pre = [2]int{preReleasePhases[m[1]], number(m[2])}
+	}
+	if m[3] != "" { // 1.0-1 は 1.0.post1 と同じ
+		key.post = number(m[3])
+	} else if m[4] != "" {
+		key.post = number(m[5])
+	}
+	if m[6] != "" {
+		key.dev = number(m[7])
+		if m[1] == "" && key.post < 0 {
+			key.pre = [2]int{-1, 0}
+		}
+	}
+	return key
+}
+
+// バージョンを比較する（リリース番号の省略された要素は 0 とみなす）
+func compareVersions(a, b string) int {
+	va, vb := parseVersion(a), parseVersion(b)
+	for i := 0; i < len(va.release) || i < len(vb.release); i++ {
+		var x, y int
+		if i < len(va.release) {
+			x = va.release[i]
+		}
+		if i < len(vb.release) {
+			y = vb.release[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y)
+		}
+	}
+	if c := cmp.Compare(va.pre[0], vb.pre[0]); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(va.pre[1], vb.pre[1]); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(va.post, vb.post); c != 0 {
+		return c
+	}
+	return cmp.Compare(va.dev, vb.dev)
+}
+
+// リリース番号が prefix で始まるか（省略された要素は 0 とみなす）
+func hasPrefix(numbers, prefix []int) bool {
+	for i, p := range prefix {
+		n := 0
+		if i < len(numbers) {
+			n = numbers[i]
+		}
+		if n != p {
+			return false
+		}
+	}
+	return true
+}
+
+// パッケージ名を正規化する（大文字小文字と -_. の違いを無視する）
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparatorPattern.ReplaceAllString(name, "-"))
+}
+
+// 仮想環境にインストールされているパッケージ（正規化した名前 → バージョン）
+// site-packages の *.dist-info ディレクトリ名から求める
+func installedPackages(venvDir string) (map[string]string, error) {
+	var matches []string
+	for _, pattern := range []string{
+		filepath.Join(venvDir, "lib", "python*", "site-packages", "*.dist-info"),
+		filepath.Join(venvDir, "Lib", "site-packages", "*.dist-info"),
+	} {
+		found, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("インストール済みのパッケージの確認に失敗: %v", err)
+		}
+		matches = append(matches, found...)
+	}
+
+	installed := make(map[string]string)
+	for _, match := range matches {
+		base := strings.TrimSuffix(filepath.Base(match), ".dist-info")
+		i := strings.LastIndex(base, "-")
+		if i < 0 {
+			continue
+		}
+		installed[normalizeName(base[:i])] = base[i+1:]
+	}
+	return installed, nil
+}
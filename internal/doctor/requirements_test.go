@@ -0,0 +1,204 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRequirementsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []requirement
+		wantErr string
+	}{
+		{
+			name:    "バージョン指定",
+			content: "Flask>=2.3.0\nFlask-SQLAlchemy >= 3.0, <4\n",
+			want: []requirement{
+				{Name: "Flask", Specifiers: []specifier{{">=", "2.3.0"}}},
+				{Name: "Flask-SQLAlchemy", Specifiers: []specifier{{">=", "3.0"}, {"<", "4"}}},
+			},
+		},
+		{
+			name:    "コメント・オプション行・空行",
+			content: "# 依存関係\n-r base.txt\n--index-url https://example.com/simple\n\nrequests==2.31.0  # HTTP\n",
+			want: []requirement{
+				{Name: "requests", Specifiers: []specifier{{"==", "2.31.0"}}},
+			},
+		},
+		{
+			name:    "extras・環境マーカー・URL 指定",
+			content: "uvicorn[standard]~=0.23\ntomli>=2.0; python_version < \"3.11\"\nmypkg @ https://example.com/mypkg.zip\nclick\n",
+			want: []requirement{
+				{Name: "uvicorn", Specifiers: []specifier{{"~=", "0.23"}}},
+				{Name: "tomli", Specifiers: []specifier{{">=", "2.0"}}},
+				{Name: "mypkg"},
+				{Name: "click"},
+			},
+		},
+		{
+			name:    "解析できない行",
+			content: "Flask\n!!!\n",
+			wantErr: "requirements.txt:2: 解析できない行です",
+		},
+		{
+			name:    "不正なバージョン指定",
+			content: "Flask=>2.0\n",
+			wantErr: "requirements.txt:1: 不正なバージョン指定です",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "requirements.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseRequirementsFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequirementsFileNotExist(t *testing.T) {
+	_, err := parseRequirementsFile(filepath.Join(t.TempDir(), "requirements.txt"))
+	if !os.IsNotExist(err) {
+		t.Errorf("error = %v, want not exist", err)
+	}
+}
+
+func TestSpecifierMatches(t *testing.T) {
+	tests := []struct {
+		op, spec, version string
+		want              bool
+	}{
+		{">=", "2.3.0", "2.3.0", true},
+		{">=", "2.3.0", "2.10.1", true},
+		{">=", "2.3.0", "2.2.9", false},
+		{">", "2.3", "2.3.0", false},
+		{"<", "3", "2.99", true},
+		{"<=", "2.3", "2.3.0", true},
+		{"==", "2.3", "2.3.0", true},
+		{"==", "2.3.0", "2.3.1", false},
+		{"!=", "2.3.0", "2.3.1", true},
+
+		// ~=X.Y は >=X.Y かつ X.*
+		{"~=", "2.3", "2.3.0", true},
+		{"~=", "2.3", "2.9", true},
+		{"~=", "2.3", "3.0", false},
+		{"~=", "2.3", "2.2", false},
+		{"~=", "2.3.1", "2.3.5", true},
+		{"~=", "2.3.1", "2.4.0", false},
+		{"~=", "2", "3.0", true},
+
+		// ワイルドカード
+		{"==", "2.3.*", "2.3.7", true},
+		{"==", "2.3.*", "2.30.0", false},
+		{"!=", "2.3.*", "2.3.7", false},
+		{"!=", "2.3.*", "2.4.0", true},
+		{"!=", "2.*", "3.0.0", true},
+
+		// 開発版 < プレリリース < 正式版 < ポストリリース（PEP 440）
+		{">=", "2.3.0", "2.3.0rc1", false},
+		{">=", "2.3.0", "2.3.0.post1", true},
+		{"==", "2.3.0", "2.3.0.post1", false},
+		{"<", "3.0", "3.0.0b2", true},
+		{"<", "3.0", "3.0.0.dev1", true},
+		{">=", "2.3.0rc1", "2.3.0rc2", true},
+		{">=", "2.3.0b1", "2.3.0a5", false},
+		{">", "2.3.0", "2.3.0-1", true},
+		{"~=", "1.0", "1.5.dev1", true},
+		{"==", "2.3.*", "2.3.0rc1", true},
+
+		// === は文字列として比較する
+		{"===", "2.3.0", "2.3.0", true},
+		{"===", "2.3", "2.3.0", false},
+	}
+
+	for _, tt := range tests {
+		s := specifier{Op: tt.op, Version: tt.spec}
+		if got := s.matches(tt.version); got != tt.want {
+			t.Errorf("%s%s matches %q = %v, want %v", tt.op, tt.spec, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	// 昇順に並べたバージョン
+	ordered := []string{
+		"1.0.dev0",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0a2",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0.post1.dev1",
+		"1.0.post1",
+		"1.0.1",
+		"1.1.dev1",
+		"2.0",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			got := compareVersions(a, b)
+			if (got < 0) != (i < j) || (got == 0) != (i == j) {
+				t.Errorf("compareVersions(%q, %q) = %d", a, b, got)
+			}
+		}
+	}
+
+	// 表記の違い
+	equal := [][2]string{
+		{"1.0", "1.0.0"},
+		{"1.0rc1", "1.0c1"},
+		{"1.0-rc.1", "1.0rc1"},
+		{"1.0alpha1", "1.0a1"},
+		{"1.0-1", "1.0.post1"},
+		{"1.0.rev1", "1.0.post1"},
+		{"1.0+local", "1.0"},
+		{"v1.0", "1.0"},
+	}
+	for _, pair := range equal {
+		if got := compareVersions(pair[0], pair[1]); got != 0 {
+			t.Errorf("compareVersions(%q, %q) = %d, want 0", pair[0], pair[1], got)
+		}
+	}
+}
+
+func TestReleaseNumbers(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []int
+	}{
+		{"2.3.0", []int{2, 3, 0}},
+		{"2024.1", []int{2024, 1}},
+		{"2.3.0rc1", []int{2, 3, 0}},
+		{"3.12.0b2", []int{3, 12, 0}},
+		{"1.0.post1", []int{1, 0}},
+		{"1.0.dev1", []int{1, 0}},
+		{"abc", nil},
+	}
+
+	for _, tt := range tests {
+		if got := releaseNumbers(tt.version); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("releaseNumbers(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
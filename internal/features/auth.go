@@ -0,0 +1,17 @@
+package features
+
+import "github.com/KOU050223/flasgo/internal/templates"
+
+// 認証（Flask-Login）
+// ユーザーモデルとログインフォームを使うためデータベースとフォームを必要とする
+var Auth Feature = &spec{
+	id:           "auth",
+	label:        "認証機能 (Flask-Login)",
+	dependencies: []string{"database", "forms"},
+	requirements: []string{"Flask-Login>=0.6.0"},
+	layers: []templates.Layer{
+		{Dir: "features/auth/html", When: ".UsesTemplatesDir"},
+		{Dir: "features/auth/blueprint", When: `eq .Structure "blueprint"`},
+	},
+	readme: "ユーザー認証（Flask-Login）",
+}
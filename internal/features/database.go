@@ -0,0 +1,19 @@
+package features
+
+import "github.com/KOU050223/flasgo/internal/templates"
+
+// データベース（Flask-SQLAlchemy + Flask-Migrate）
+// シンプル・標準構造では app.py に、Blueprint構造では app/models.py にモデルを定義する
+// Web UI だけのアプリでは /users でユーザーの一覧を表示する（templates/users.html）
+// シーダーは全ての構造で seeders/ に置き、flask seed コマンドで実行する
+var Database Feature = &spec{
+	id:           "database",
+	label:        "データベース (SQLAlchemy)",
+	requirements: []string{"Flask-SQLAlchemy>=3.0.0", "Flask-Migrate>=4.0.0"},
+	layers: []templates.Layer{
+		{Dir: "features/database/html", When: `and .UsesTemplatesDir (ne .AppType "fullstack")`},
+		{Dir: "features/database/blueprint", When: `eq .Structure "blueprint"`},
+		{Dir: "features/database/seeders", When: `not .SingleFileApp`},
+	},
+	readme: "データベース連携（SQLAlchemy、Flask-Migrate によるマイグレーション）",
+}
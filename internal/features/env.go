@@ -0,0 +1,14 @@
+package features
+
+import "github.com/KOU050223/flasgo/internal/templates"
+
+// 環境変数（python-dotenv で .env を読み込む）
+var Env Feature = &spec{
+	id:           "env",
+	label:        "環境変数管理 (.env)",
+	requirements: []string{"python-dotenv>=1.0.0"},
+	layers: []templates.Layer{
+		{Dir: "features/env/dotenv"},
+	},
+	readme: "環境変数管理（python-dotenv）",
+}
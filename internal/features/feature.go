@@ -0,0 +1,120 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/templates"
+)
+
+// 追加機能
+//
+// 機能ごとに必要なパッケージ・テンプレート・README の記述をまとめたもの。
+// 有効にした機能はテンプレートから .Features.<ID> で参照できる。
+// 共通のテンプレートに差し込むコードは features/<ID>/partials/ の部品として定義する（{{slot}}）。
+type Feature interface {
+	ID() string                // 識別子（--features や .flasgo.json に記録する値）
+	Label() string             // 選択肢に表示する名前
+	Dependencies() []string    // 一緒に有効にする機能
+	Requirements() []string    // requirements.txt に追加するパッケージ
+	Layers() []templates.Layer // 機能を有効にしたときに追加するテンプレートのレイヤー
+	Readme() string            // README の「機能」に追加する項目（空なら追加しない）
+}
+
+// 宣言的に定義した機能
+type spec struct {
+	id           string
+	label        string
+	dependencies []string
+	requirements []string
+	layers       []templates.Layer
+	readme       string
+}
+
+func (s *spec) ID() string                { return s.id }
+func (s *spec) Label() string             { return s.label }
+func (s *spec) Dependencies() []string    { return s.dependencies }
+func (s *spec) Requirements() []string    { return s.requirements }
+func (s *spec) Layers() []templates.Layer { return s.layers }
+func (s *spec) Readme() string            { return s.readme }
+
+// 登録済みの機能（選択肢・requirements.txt・README はこの順に並ぶ）
+var registry = []Feature{
+	Database,
+	Auth,
+	Forms,
+	Env,
+}
+
+// 機能を登録する（同じ ID の機能は登録できない）
+func Register(feature Feature) {
+	if _, ok := Get(feature.ID()); ok {
+		panic(fmt.Sprintf("機能 '%s' は既に登録されています", feature.ID()))
+	}
+	registry = append(registry, feature)
+}
+
+// 登録済みの全ての機能
+func All() []Feature {
+	return registry
+}
+
+// ID から機能を取得
+func Get(id string) (Feature, bool) {
+	for _, feature := range registry {
+		if feature.ID() == id {
+			return feature, true
+		}
+	}
+	return nil, false
+}
+
+// 登録済みの機能の ID をカンマ区切りで列挙
+func IDs() string {
+	ids := make([]string, len(registry))
+	for i, feature := range registry {
+		ids[i] = feature.ID()
+	}
+	return strings.Join(ids, ", ")
+}
+
+// 機能の依存関係を解決して不足している機能を追加
+// 依存先の機能がさらに依存する機能も追加する
+func Resolve(ids []string) []string {
+	has := make(map[string]bool)
+	for _, id := range ids {
+		has[id] = true
+	}
+
+	resolved := append([]string{}, ids...)
+	for i := 0; i < len(resolved); i++ {
+		feature, ok := Get(resolved[i])
+		if !ok {
+			continue
+		}
+		for _, dep := range feature.Dependencies() {
+			if !has[dep] {
+				resolved = append(resolved, dep)
+				has[dep] = true
+			}
+		}
+	}
+
+	return resolved
+}
+
+// 有効な機能を登録順に返す
+func Enabled(ids []string) []Feature {
+	has := make(map[string]bool)
+	for _, id := range ids {
+		has[id] = true
+	}
+
+	var enabled []Feature
+	for _, feature := range registry {
+		if has[feature.ID()] {
+			enabled = append(enabled, feature)
+		}
+	}
+	return enabled
+}
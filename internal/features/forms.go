@@ -0,0 +1,15 @@
+package features
+
+import "github.com/KOU050223/flasgo/internal/templates"
+
+// フォーム（Flask-WTF）
+var Forms Feature = &spec{
+	id:           "forms",
+	label:        "フォーム処理 (Flask-WTF)",
+	requirements: []string{"Flask-WTF>=1.1.0", "WTForms>=3.0.0"},
+	layers: []templates.Layer{
+		{Dir: "features/forms/html", When: ".UsesTemplatesDir"},
+		{Dir: "features/forms/blueprint", When: `eq .Structure "blueprint"`},
+	},
+	readme: "フォーム処理（Flask-WTF）",
+}
@@ -0,0 +1,199 @@
+package filemaker
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/cli"
+	"github.com/KOU050223/flasgo/internal/features"
+	"github.com/KOU050223/flasgo/internal/manifest"
+)
+
+// add コマンド: 既存プロジェクトに追加機能を有効化する
+//
+//	flasgo add <feature> [--dir DIR] [--force | --skip-existing]
+func Add(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	force := fs.Bool("force", false, "変更済みのファイルも上書きする")
+	skipExisting := fs.Bool("skip-existing", false, "変更済みのファイルは上書きせずに残す")
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("追加する機能を1つ指定してください (%s)", features.IDs())
+	}
+	feature := positional[0]
+	if _, ok := features.Get(feature); !ok {
+		return fmt.Errorf("不明な機能: %s (%s から選択してください)", feature, features.IDs())
+	}
+
+	policy, err := conflictPolicy(*force, *skipExisting)
+	if err != nil {
+		return err
+	}
+
+	return addFeature(*dir, feature, policy)
+}
+
+// マニフェストの設定に機能を追加してプロジェクトを再生成する
+// ユーザーが変更したファイルは policy に従い、requirements.txt と .env は追記でマージする
+func addFeature(root, feature string, policy ConflictPolicy) error {
+	previous, err := manifest.Load(root)
+	if err != nil {
+		return err
+	}
+
+	config := previous.Config
+	for _, enabled := range config.Features {
+		if enabled == feature {
+			return fmt.Errorf("機能 '%s' は既に有効です", feature)
+		}
+	}
+	config.Features = append(append([]string{}, config.Features...), feature)
+
+	fmt.Printf("➕ 機能 '%s' を追加します\n\n", feature)
+
+	plan, err := buildProjectPlan(&config)
+	if err != nil {
+		return err
+	}
+
+	// requirements.txt と .env は既存の内容に不足分を追記する（ユーザーの追加分を残すため常にマージ）
+	var merged []projectFile
+	var files []projectFile
+	for _, file := range plan.files {
+		mergeFunc, ok := mergeableFiles[filepath.ToSlash(file.path)]
+		if !ok {
+			files = append(files, file)
+			continue
+		}
+
+		existing, err := os.ReadFile(filepath.Join(root, file.path))
+		if os.IsNotExist(err) {
+			files = append(files, file)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("既存ファイルの読み込みに失敗 (%s): %v", file.path, err)
+		}
+
+		content := mergeFunc(string(existing), file.content)
+		if content != string(existing) {
+			fmt.Printf("  ➕ 追記: %s\n", filepath.ToSlash(file.path))
+			merged = append(merged, projectFile{file.path, content})
+		}
+	}
+	plan.files = files
+
+	resolved, err := resolveConflicts(root, plan, policy, previous)
+	if err != nil {
+		return err
+	}
+	resolved.files = append(resolved.files, merged...)
+
+	if err := planManifest(resolved, root, &config, previous); err != nil {
+		return err
+	}
+	if err := writePlanIntoExisting(root, resolved); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 機能 '%s' を追加しました（有効な機能: %s）\n", feature, strings.Join(config.Features, ", "))
+	if len(resolved.skipped) > 0 {
+		fmt.Printf("\n⚠️  以下のファイルは変更済みのためスキップしました。'%s' の内容は手動で反映してください:\n", feature)
+		for _, file := range resolved.skipped {
+			fmt.Printf("  - %s\n", filepath.ToSlash(file.path))
+		}
+	}
+	fmt.Printf("\n次のステップ:\n")
+	for _, command := range packagingToolOf(&config).install {
+		fmt.Printf("  %s\n", command)
+	}
+	return nil
+}
+
+// 既存の内容とマージして更新するファイル
+var mergeableFiles = map[string]func(existing, generated string) string{
+	"requirements.txt": mergeRequirements,
+	".env":             mergeEnv,
+}
+
+// requirements.txt の行からパッケージ名を取り出す
+var requirementNamePattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// 既存の requirements.txt に含まれていないパッケージだけを追記する
+func mergeRequirements(existing, generated string) string {
+	packageName := func(line string) string {
+		m := requirementNamePattern.FindStringSubmatch(line)
+		if m == nil {
+			return ""
+		}
+		return strings.ToLower(strings.ReplaceAll(m[1], "_", "-"))
+	}
+
+	installed := make(map[string]bool)
+	for _, line := range splitLines(existing) {
+		if name := packageName(line); name != "" {
+			installed[name] = true
+		}
+	}
+
+	var missing []string
+	for _, line := range splitLines(generated) {
+		if name := packageName(line); name != "" && !installed[name] {
+			missing = append(missing, line)
+		}
+	}
+
+	return appendLines(existing, missing)
+}
+
+// 既存の .env に定義されていない変数だけを追記する
+func mergeEnv(existing, generated string) string {
+	envKey := func(line string) string {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			return ""
+		}
+		key, _, _ := strings.Cut(line, "=")
+		return strings.TrimSpace(strings.TrimPrefix(key, "export "))
+	}
+
+	defined := make(map[string]bool)
+	for _, line := range splitLines(existing) {
+		if key := envKey(line); key != "" {
+			defined[key] = true
+		}
+	}
+
+	var missing []string
+	for _, line := range splitLines(generated) {
+		if key := envKey(line); key != "" && !defined[key] {
+			missing = append(missing, line)
+		}
+	}
+
+	return appendLines(existing, missing)
+}
+
+// テキストの末尾に行を追加する（追加する行がなければそのまま返す）
+func appendLines(text string, lines []string) string {
+	if len(lines) == 0 {
+		return text
+	}
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text + strings.Join(lines, "\n") + "\n"
+}
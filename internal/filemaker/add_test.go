@@ -0,0 +1,105 @@
+package filemaker
+
+import "testing"
+
+func TestMergeRequirements(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+	}{
+		{
+			name:      "不足しているパッケージだけを追記",
+			existing:  "Flask>=2.3.0\npython-dotenv>=1.0.0\n",
+			generated: "Flask>=2.3.0\nFlask-Login>=0.6.0\npython-dotenv>=1.0.0\n",
+			want:      "Flask>=2.3.0\npython-dotenv>=1.0.0\nFlask-Login>=0.6.0\n",
+		},
+		{
+			name:      "バージョン指定が違っても追記しない",
+			existing:  "Flask==3.0.0\n",
+			generated: "Flask>=2.3.0\n",
+			want:      "Flask==3.0.0\n",
+		},
+		{
+			name:      "大文字小文字と _ の違いを無視",
+			existing:  "flask_sqlalchemy==3.1.1\n",
+			generated: "Flask-SQLAlchemy>=3.0.0\n",
+			want:      "flask_sqlalchemy==3.1.1\n",
+		},
+		{
+			name:      "コメント・オプション行は追記しない",
+			existing:  "Flask\n",
+			generated: "# 追加機能\n-r base.txt\nFlask-WTF>=1.1.0\n",
+			want:      "Flask\nFlask-WTF>=1.1.0\n",
+		},
+		{
+			name:      "末尾に改行がないファイル",
+			existing:  "Flask",
+			generated: "Flask\nWTForms>=3.0.0\n",
+			want:      "Flask\nWTForms>=3.0.0\n",
+		},
+		{
+			name:      "空のファイル",
+			existing:  "",
+			generated: "Flask>=2.3.0\n",
+			want:      "Flask>=2.3.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeRequirements(tt.existing, tt.generated); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+	}{
+		{
+			name:      "定義されていない変数だけを追記",
+			existing:  "SECRET_KEY=abc\n",
+			generated: "SECRET_KEY=your-secret-key-here\nDATABASE_URL=sqlite:///app.db\n",
+			want:      "SECRET_KEY=abc\nDATABASE_URL=sqlite:///app.db\n",
+		},
+		{
+			name:      "export 付きの定義",
+			existing:  "export SECRET_KEY=abc\nexport  DEBUG=False\n",
+			generated: "SECRET_KEY=your-secret-key-here\nDEBUG=True\n",
+			want:      "export SECRET_KEY=abc\nexport  DEBUG=False\n",
+		},
+		{
+			name:      "引用符で囲んだ値",
+			existing:  "SECRET_KEY=\"a=b c\"\nDATABASE_URL='sqlite:///app.db'\n",
+			generated: "SECRET_KEY=your-secret-key-here\nDATABASE_URL=sqlite:///other.db\nDEBUG=True\n",
+			want:      "SECRET_KEY=\"a=b c\"\nDATABASE_URL='sqlite:///app.db'\nDEBUG=True\n",
+		},
+		{
+			name:      "= の前後の空白",
+			existing:  "SECRET_KEY = abc\n",
+			generated: "SECRET_KEY=your-secret-key-here\n",
+			want:      "SECRET_KEY = abc\n",
+		},
+		{
+			name:      "コメントの変数は未定義として扱う",
+			existing:  "# DEBUG=True\n",
+			generated: "# Other configurations\nDEBUG=True\n",
+			want:      "# DEBUG=True\nDEBUG=True\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeEnv(tt.existing, tt.generated); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
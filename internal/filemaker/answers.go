@@ -0,0 +1,60 @@
+package filemaker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KOU050223/flasgo/types"
+	"gopkg.in/yaml.v3"
+)
+
+// 回答ファイル（YAML / JSON）からプロジェクト設定を読み込む
+// 未知のキーは将来のオプション用として無視する
+func loadAnswers(path string) (*types.ProjectConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("回答ファイルの読み込みに失敗 (%s): %v", path, err)
+	}
+
+	config := &types.ProjectConfig{}
+	if isJSONFile(path) {
+		err = json.Unmarshal(content, config)
+	} else {
+		err = yaml.Unmarshal(content, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("回答ファイルの解析に失敗 (%s): %v", path, err)
+	}
+
+	return config, nil
+}
+
+// プロジェクト設定を回答ファイル（YAML / JSON）に書き出す
+func saveAnswers(path string, config *types.ProjectConfig) error {
+	var content []byte
+	var err error
+	if isJSONFile(path) {
+		content, err = json.MarshalIndent(config, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = yaml.Marshal(config)
+	}
+	if err != nil {
+		return fmt.Errorf("回答ファイルの生成に失敗: %v", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("回答ファイルの書き込みに失敗 (%s): %v", path, err)
+	}
+
+	fmt.Printf("📝 回答を %s に保存しました\n", path)
+	return nil
+}
+
+// 拡張子が .json かどうか（それ以外は YAML として扱う）
+func isJSONFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
@@ -0,0 +1,108 @@
+package filemaker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KOU050223/flasgo/internal/manifest"
+	"github.com/KOU050223/flasgo/internal/ui"
+)
+
+// 既存ファイルと衝突した場合の扱い
+type ConflictPolicy int
+
+const (
+	ConflictAsk       ConflictPolicy = iota // ファイルごとに確認する
+	ConflictOverwrite                       // 全て上書きする (--force)
+	ConflictSkip                            // 既存ファイルは残す (--skip-existing)
+)
+
+// 衝突時の選択肢
+const (
+	choiceSkip         = "skip"
+	choiceOverwrite    = "overwrite"
+	choiceDiff         = "diff"
+	choiceOverwriteAll = "overwrite-all"
+	choiceSkipAll      = "skip-all"
+)
+
+// 既存ファイルとの衝突を解決し、実際に書き込むファイルだけの一覧を返す
+// previous（既存のマニフェスト）があれば、生成時から変更されていないファイルは確認せずに更新する
+func resolveConflicts(root string, plan *projectPlan, policy ConflictPolicy, previous *manifest.Manifest) (*projectPlan, error) {
+	resolved := &projectPlan{dirs: plan.dirs}
+
+	for _, file := range plan.files {
+		path := filepath.Join(root, file.path)
+		existing, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			resolved.files = append(resolved.files, file)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("既存ファイルの読み込みに失敗 (%s): %v", path, err)
+		}
+
+		// 内容が同じなら何もしない
+		if bytes.Equal(existing, []byte(file.content)) {
+			resolved.unchanged = append(resolved.unchanged, file)
+			continue
+		}
+
+		// flasgo が生成したままのファイルはユーザーの変更がないので更新してよい
+		if previous != nil && previous.Unmodified(root, file.path) {
+			fmt.Printf("  🔄 更新: %s\n", filepath.ToSlash(file.path))
+			resolved.files = append(resolved.files, file)
+			continue
+		}
+
+		overwrite := false
+		switch policy {
+		case ConflictOverwrite:
+			overwrite = true
+		case ConflictSkip:
+			overwrite = false
+		default:
+			switch promptConflict(file, string(existing)) {
+			case choiceOverwrite:
+				overwrite = true
+			case choiceOverwriteAll:
+				overwrite = true
+				policy = ConflictOverwrite
+			case choiceSkipAll:
+				policy = ConflictSkip
+			}
+		}
+
+		if overwrite {
+			fmt.Printf("  ✏️  上書き: %s\n", filepath.ToSlash(file.path))
+			resolved.files = append(resolved.files, file)
+		} else {
+			fmt.Printf("  ⏭️  スキップ: %s\n", filepath.ToSlash(file.path))
+			resolved.skipped = append(resolved.skipped, file)
+		}
+	}
+
+	return resolved, nil
+}
+
+// 衝突したファイルの扱いをユーザーに確認する
+func promptConflict(file projectFile, existing string) string {
+	options := []ui.Option{
+		{Label: "スキップする（既存ファイルを残す）", Value: choiceSkip},
+		{Label: "上書きする", Value: choiceOverwrite},
+		{Label: "差分を表示する", Value: choiceDiff},
+		{Label: "以降すべて上書きする", Value: choiceOverwriteAll},
+		{Label: "以降すべてスキップする", Value: choiceSkipAll},
+	}
+
+	question := fmt.Sprintf("%s は既に存在します。どうしますか？", filepath.ToSlash(file.path))
+	for {
+		choice := ui.PromptSelect(question, options)
+		if choice != choiceDiff {
+			return choice
+		}
+		fmt.Print(unifiedDiff(existing, file.content, "既存: "+filepath.ToSlash(file.path), "生成: "+filepath.ToSlash(file.path)))
+	}
+}
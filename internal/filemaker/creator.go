@@ -1,228 +1,468 @@
 package filemaker
 
 import (
-	"bytes"
 	"fmt"
+	"github.com/KOU050223/flasgo/internal/features"
+	"github.com/KOU050223/flasgo/internal/manifest"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/python"
 	"github.com/KOU050223/flasgo/internal/templates"
+	"github.com/KOU050223/flasgo/internal/version"
 	"github.com/KOU050223/flasgo/types"
 	"os"
 	"path/filepath"
-	"text/template"
 )
 
 // テンプレート用のデータ構造
 type TemplateData struct {
-	ProjectName string
-	HasDatabase bool
-	HasAuth     bool
-	HasForms    bool
-	HasEnv      bool
+	ProjectName string // 入力されたプロジェクト名（ディレクトリ名）
+	PackageName string // Python のパッケージ名（例: my_app）
+	Slug        string // スラッグ（例: my-app）
+	Title       string // 表示用のタイトル（例: My App）
+	AppType     string
+	Structure   string
+	Packaging   string // 依存関係の管理ツール (pip, pip-tools, poetry, uv)
+	Python      string // Python の最小バージョン（指定しない場合は空文字）
+	MinPython   string // Python の最小バージョン（指定しない場合は Flask が必要とするバージョン）
+
+	Dependencies    []string // 依存パッケージ（requirements.txt / pyproject.toml）
+	InstallCommands []string // 依存関係のインストール手順
+	RunPrefix       string   // 仮想環境内でコマンドを実行するための接頭辞（例: "uv run "）
+
+	Features      map[string]bool // 登録済みの機能ごとに有効かどうか（.Features.database など）
+	FeatureReadme []string        // README の「機能」に追加する項目
+
+	enabledFeatures []string // 有効な機能の ID（登録順、{{slot}} で部品を処理する順序）
+
+	FlashCategories []FlashCategory // base.html で flash() のカテゴリをアラートの種類に対応させる
 }
 
-// プロジェクト作成のメイン関数
-func createProject(config *types.ProjectConfig) error {
-	// プロジェクトディレクトリが既に存在するかチェック
-	if _, err := os.Stat(config.Name); !os.IsNotExist(err) {
-		return fmt.Errorf("プロジェクトディレクトリ '%s' は既に存在します", config.Name)
-	}
+// 全てのプロジェクトで必要なパッケージ
+var baseDependencies = []string{"Flask>=" + python.FlaskVersion}
 
-	// プロジェクトディレクトリを作成
-	if err := os.MkdirAll(config.Name, 0755); err != nil {
-		return fmt.Errorf("プロジェクトディレクトリの作成に失敗: %v", err)
-	}
+// アプリタイプごとに必要なパッケージ
+var appTypeDependencies = map[string][]string{
+	"api": {"Flask-CORS>=4.0.0"},
+}
 
-	// テンプレートデータを準備
-	templateData := prepareTemplateData(config)
+// flash() のカテゴリと Bootstrap のアラートの種類の対応
+type FlashCategory struct {
+	Name  string // flash(message, category) のカテゴリ
+	Class string // alert-* のクラス名
+}
 
-	// プロジェクト構造に応じてファイルを作成
-	switch config.Structure {
-	case "simple":
-		return createSimpleStructure(config, templateData)
-	case "standard":
-		return createStandardStructure(config, templateData)
-	case "blueprint":
-		return createBlueprintStructure(config, templateData)
-	default:
-		return fmt.Errorf("不明なプロジェクト構造: %s", config.Structure)
+// 生成するアプリで使う flash() のカテゴリ（"message" は flash() のデフォルト）
+var flashCategories = []FlashCategory{
+	{Name: "message", Class: "info"},
+	{Name: "success", Class: "success"},
+	{Name: "error", Class: "danger"},
+	{Name: "warning", Class: "warning"},
+}
+
+// Hello World の1ファイルのアプリ（app-hello）を使うか
+// シンプル構造の hello で、アプリのコードを必要とする機能を選んでいない場合に限る
+// （env は .env を置くだけで flask run が読み込むので1ファイルのままにする）。
+// それ以外の hello は標準構造と同じく webapp のアプリで機能を組み込む
+func (d *TemplateData) SingleFileApp() bool {
+	if d.Structure != "simple" || d.AppType != "hello" {
+		return false
 	}
+	for id, enabled := range d.Features {
+		if enabled && id != "env" {
+			return false
+		}
+	}
+	return true
 }
 
-// テンプレートデータを準備
-func prepareTemplateData(config *types.ProjectConfig) *TemplateData {
-	data := &TemplateData{
-		ProjectName: config.Name,
+// 設定値を読み込む Python の式（fallback は既定値の式）
+// env 機能が有効なら環境変数（.env）の値を優先する
+func (d *TemplateData) Setting(name, fallback string) string {
+	if d.Features["env"] {
+		return fmt.Sprintf("os.environ.get('%s') or %s", name, fallback)
 	}
+	return fallback
+}
 
-	for _, feature := range config.Features {
-		switch feature {
-		case "database":
-			data.HasDatabase = true
-		case "auth":
-			data.HasAuth = true
-		case "forms":
-			data.HasForms = true
-		case "env":
-			data.HasEnv = true
-		}
+// templates/ 以下のHTMLテンプレートを使うか（シンプル・標準構造で render_template を使う場合）
+func (d *TemplateData) UsesTemplatesDir() bool {
+	if d.Structure == "blueprint" || d.AppType == "api" {
+		return false
 	}
+	return !d.SingleFileApp()
+}
 
-	return data
+// Jinja の url_for に渡すエンドポイント名
+// Blueprint構造ではブループリント名を付ける（例: main.index）
+func (d *TemplateData) Endpoint(blueprint, name string) string {
+	if d.Structure == "blueprint" {
+		return blueprint + "." + name
+	}
+	return name
 }
 
-// シンプル構造（1ファイル）を作成
-func createSimpleStructure(config *types.ProjectConfig, data *TemplateData) error {
-	appPath := filepath.Join(config.Name, "app.py")
+// 作成するファイル（プロジェクトルートからの相対パスと内容）
+type projectFile struct {
+	path    string
+	content string
+}
 
-	// アプリタイプに応じたテンプレートを選択
-	var content string
-	switch config.Type {
-	case "hello":
-		content = templates.HelloWorldApp
-	case "webapp":
-		content = processTemplate(templates.WebAppMain, data)
-	case "api":
-		content = processTemplate(templates.APIMain, data)
-	default:
-		content = templates.HelloWorldApp
+// 作成するディレクトリとファイルの一覧
+type projectPlan struct {
+	dirs      []string
+	files     []projectFile
+	unchanged []projectFile // 既存ファイルと同じ内容のため書き込まないファイル
+	skipped   []projectFile // 既存ファイルを残すため書き込まないファイル
+}
+
+// ディレクトリを追加
+func (p *projectPlan) addDir(elem ...string) {
+	p.dirs = append(p.dirs, filepath.Join(elem...))
+}
+
+// ファイルを追加
+func (p *projectPlan) addFile(path, content string) {
+	p.files = append(p.files, projectFile{path, content})
+}
+
+// プロジェクト作成のメイン関数
+// 作成先が既に存在する場合は policy に従って既存ファイルとの衝突を解決する
+func createProject(config *types.ProjectConfig, policy ConflictPolicy) error {
+	root := projectDir(config)
+
+	plan, err := buildProjectPlan(config)
+	if err != nil {
+		return err
+	}
+
+	// プロジェクトディレクトリが既に存在するかチェック
+	info, err := os.Stat(root)
+	if os.IsNotExist(err) {
+		if err := planManifest(plan, root, config, nil); err != nil {
+			return err
+		}
+		return writePlanAtomically(root, plan)
+	}
+	if err != nil {
+		return fmt.Errorf("プロジェクトディレクトリの確認に失敗: %v", err)
 	}
+	if !info.IsDir() {
+		return fmt.Errorf("'%s' はディレクトリではありません", root)
+	}
+
+	fmt.Printf("📂 既存のディレクトリ '%s' に作成します\n", root)
+	// 既存のマニフェストがあれば記録を引き継ぐ
+	previous, _ := manifest.Load(root)
 
-	if err := writeFile(appPath, content); err != nil {
+	resolved, err := resolveConflicts(root, plan, policy, previous)
+	if err != nil {
 		return err
 	}
 
-	// requirements.txtを作成
-	reqPath := filepath.Join(config.Name, "requirements.txt")
-	reqContent := templates.GenerateRequirements(config.Features, config.Type)
-	if err := writeFile(reqPath, reqContent); err != nil {
+	if err := planManifest(resolved, root, config, previous); err != nil {
 		return err
 	}
 
-	// .envファイルを作成（必要な場合）
-	if data.HasEnv {
-		envPath := filepath.Join(config.Name, ".env")
-		if err := writeFile(envPath, templates.EnvTemplate); err != nil {
+	return writePlanIntoExisting(root, resolved)
+}
+
+// 生成したファイルのチェックサムを記録したマニフェストを一覧に追加
+// スキップしたファイルはユーザーのものなので記録しない
+func planManifest(plan *projectPlan, root string, config *types.ProjectConfig, previous *manifest.Manifest) error {
+	m := &manifest.Manifest{}
+	if previous != nil {
+		m.Files = previous.Files
+	}
+
+	m.FlasgoVersion = version.Version
+	m.Config = *config
+	m.Config.Path = ""
+	if config.TemplateDir != "" {
+		// マニフェストはリポジトリで共有するので、プロジェクトからの相対パスで記録する
+		dir, err := manifest.RelativeTemplateDir(root, config.TemplateDir)
+		if err != nil {
 			return err
 		}
+		m.Config.TemplateDir = dir
 	}
 
-	// README.mdを作成
-	readmePath := filepath.Join(config.Name, "README.md")
-	readmeContent := templates.GenerateReadme(config.Name, config.Type, data.HasDatabase, data.HasForms)
-	if err := writeFile(readmePath, readmeContent); err != nil {
-		return err
+	m.Templates = map[string]string{"flask": templates.Versions["flask"]}
+	if config.Structure == "blueprint" {
+		m.Templates["blueprint"] = templates.Versions["blueprint"]
+	}
+	for _, feature := range config.Features {
+		if v, ok := templates.Versions[feature]; ok {
+			m.Templates[feature] = v
+		}
 	}
 
-	// .gitignoreを作成
-	gitignorePath := filepath.Join(config.Name, ".gitignore")
-	if err := writeFile(gitignorePath, templates.GitignoreTemplate); err != nil {
-		return err
+	for _, file := range plan.files {
+		m.Record(file.path, []byte(file.content))
+	}
+	for _, file := range plan.unchanged {
+		m.Record(file.path, []byte(file.content))
 	}
 
+	content, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	plan.addFile(manifest.FileName, content)
 	return nil
 }
 
-// 標準構造を作成
-func createStandardStructure(config *types.ProjectConfig, data *TemplateData) error {
-	// ディレクトリ構造を作成
-	dirs := []string{
-		filepath.Join(config.Name, "templates"),
-		filepath.Join(config.Name, "static", "css"),
-		filepath.Join(config.Name, "static", "js"),
+// ステージングディレクトリに書き込んでから作成先へ移動する
+// 失敗した場合は途中まで作成したファイル・ディレクトリを全て削除する
+func writePlanAtomically(root string, plan *projectPlan) (err error) {
+	parent := filepath.Dir(root)
+
+	// 作成先の親ディレクトリ（--dir 指定時など）を必要に応じて作成
+	createdParent := firstMissingDir(parent)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("プロジェクトディレクトリの作成に失敗: %v", err)
 	}
+	defer func() {
+		if err != nil && createdParent != "" {
+			os.RemoveAll(createdParent)
+		}
+	}()
 
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("ディレクトリ作成エラー: %v", err)
+	// rename できるように同じ親ディレクトリ内にステージングディレクトリを作成
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(root)+".flasgo-*")
+	if err != nil {
+		return fmt.Errorf("ステージングディレクトリの作成に失敗: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(staging)
 		}
+	}()
+
+	if err := writePlan(staging, plan); err != nil {
+		return err
 	}
 
-	// app.pyを作成
-	appPath := filepath.Join(config.Name, "app.py")
-	var appContent string
-	switch config.Type {
-	case "webapp":
-		appContent = processTemplate(templates.WebAppMain, data)
-	case "api":
-		appContent = processTemplate(templates.APIMain, data)
-	default:
-		appContent = processTemplate(templates.WebAppMain, data)
+	// MkdirTemp は 0700 で作成するため通常のディレクトリと同じ権限に戻す
+	if err := os.Chmod(staging, 0755); err != nil {
+		return fmt.Errorf("ディレクトリの権限変更に失敗: %v", err)
 	}
 
-	if err := writeFile(appPath, appContent); err != nil {
+	if err := os.Rename(staging, root); err != nil {
+		return fmt.Errorf("プロジェクトディレクトリの配置に失敗: %v", err)
+	}
+
+	return nil
+}
+
+// 既存ディレクトリ内に書き込んだファイル（ロールバック用）
+type placedFile struct {
+	path   string // 書き込み先
+	backup string // 上書き前のファイルの退避先（新規作成なら空文字）
+}
+
+// 既存ディレクトリへ書き込む
+// 全ファイルをステージングディレクトリに書き出してから1つずつ配置し、
+// 失敗した場合は作成したファイルを削除して上書きしたファイルを元に戻す
+func writePlanIntoExisting(root string, plan *projectPlan) (err error) {
+	staging, err := os.MkdirTemp(root, ".flasgo-staging-*")
+	if err != nil {
+		return fmt.Errorf("ステージングディレクトリの作成に失敗: %v", err)
+	}
+	defer os.RemoveAll(staging)
+
+	stagedDir := filepath.Join(staging, "files")
+	backupDir := filepath.Join(staging, "backup")
+	if err := writePlan(stagedDir, plan); err != nil {
 		return err
 	}
 
-	// HTMLテンプレートを作成（APIタイプでない場合）
-	if config.Type != "api" {
-		baseTemplatePath := filepath.Join(config.Name, "templates", "base.html")
-		if err := writeFile(baseTemplatePath, templates.BaseTemplate); err != nil {
+	var createdDirs []string
+	var placed []placedFile
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(placed) - 1; i >= 0; i-- {
+			os.Remove(placed[i].path)
+			if placed[i].backup != "" {
+				os.Rename(placed[i].backup, placed[i].path)
+			}
+		}
+		for i := len(createdDirs) - 1; i >= 0; i-- {
+			os.RemoveAll(createdDirs[i])
+		}
+	}()
+
+	// ディレクトリを作成（新しく作ったものだけ記録）
+	mkdir := func(dir string) error {
+		if missing := firstMissingDir(dir); missing != "" {
+			createdDirs = append(createdDirs, missing)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("ディレクトリ作成エラー: %v", err)
+		}
+		return nil
+	}
+
+	for _, dir := range plan.dirs {
+		if err := mkdir(filepath.Join(root, dir)); err != nil {
 			return err
 		}
+	}
 
-		indexTemplatePath := filepath.Join(config.Name, "templates", "index.html")
-		if err := writeFile(indexTemplatePath, templates.IndexTemplate); err != nil {
+	created, overwritten := 0, 0
+	for _, file := range plan.files {
+		target := filepath.Join(root, file.path)
+		if err := mkdir(filepath.Dir(target)); err != nil {
 			return err
 		}
 
-		// フォーム機能がある場合
-		if data.HasForms {
-			formTemplatePath := filepath.Join(config.Name, "templates", "form.html")
-			if err := writeFile(formTemplatePath, templates.FormTemplate); err != nil {
-				return err
+		entry := placedFile{path: target}
+		if _, statErr := os.Stat(target); statErr == nil {
+			entry.backup = filepath.Join(backupDir, file.path)
+			if err := os.MkdirAll(filepath.Dir(entry.backup), 0755); err != nil {
+				return fmt.Errorf("ディレクトリ作成エラー: %v", err)
+			}
+			if err := os.Rename(target, entry.backup); err != nil {
+				return fmt.Errorf("既存ファイルの退避に失敗 (%s): %v", target, err)
 			}
+			overwritten++
+		} else {
+			created++
 		}
-	}
+		placed = append(placed, entry)
 
-	// requirements.txtを作成
-	reqPath := filepath.Join(config.Name, "requirements.txt")
-	reqContent := templates.GenerateRequirements(config.Features, config.Type)
-	if err := writeFile(reqPath, reqContent); err != nil {
-		return err
+		if err := os.Rename(filepath.Join(stagedDir, file.path), target); err != nil {
+			return fmt.Errorf("ファイルの配置に失敗 (%s): %v", target, err)
+		}
 	}
 
-	// .envファイルを作成（必要な場合）
-	if data.HasEnv {
-		envPath := filepath.Join(config.Name, ".env")
-		if err := writeFile(envPath, templates.EnvTemplate); err != nil {
-			return err
+	fmt.Printf("  %d 件作成, %d 件上書き\n", created, overwritten)
+	return nil
+}
+
+// path のうち存在しない最上位のディレクトリを返す（全て存在すれば空文字）
+func firstMissingDir(path string) string {
+	missing := ""
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return missing
+		}
+		missing = path
+
+		next := filepath.Dir(path)
+		if next == path {
+			return missing
 		}
+		path = next
 	}
+}
 
-	// README.mdを作成
-	readmePath := filepath.Join(config.Name, "README.md")
-	readmeContent := templates.GenerateReadme(config.Name, config.Type, data.HasDatabase, data.HasForms)
-	if err := writeFile(readmePath, readmeContent); err != nil {
-		return err
+// 設定から作成するディレクトリとファイルの一覧を組み立てる
+// ファイルの一覧は組み込みテンプレートのツリー（templates.Files）と
+// テンプレートパック（指定されている場合）から求める
+func buildProjectPlan(config *types.ProjectConfig) (*projectPlan, error) {
+	switch config.Structure {
+	case "simple", "standard", "blueprint":
+	default:
+		return nil, fmt.Errorf("不明なプロジェクト構造: %s", config.Structure)
 	}
 
-	// .gitignoreを作成
-	gitignorePath := filepath.Join(config.Name, ".gitignore")
-	if err := writeFile(gitignorePath, templates.GitignoreTemplate); err != nil {
-		return err
+	packDir, err := templates.FindPack(config.Pack, config.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 機能の依存関係を解決（認証機能はデータベースとフォームを必要とする）
+	config.Features = features.Resolve(config.Features)
+
+	// テンプレートデータを準備
+	templateData := prepareTemplateData(config)
+
+	// 構造ごとのレイヤーに有効な機能のレイヤーを追加
+	trees := templates.Trees(packDir)
+	layers, err := templates.Layers(trees...)
+	if err != nil {
+		return nil, err
+	}
+	for _, feature := range features.Enabled(config.Features) {
+		layers = append(layers, feature.Layers()...)
+	}
+
+	plan := &projectPlan{}
+	if err := planTemplateTree(plan, trees, layers, templateData); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// 一覧に従ってディレクトリとファイルを作成
+func writePlan(root string, plan *projectPlan) error {
+	for _, dir := range plan.dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			return fmt.Errorf("ディレクトリ作成エラー: %v", err)
+		}
+	}
+
+	for _, file := range plan.files {
+		path := filepath.Join(root, file.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("ディレクトリ作成エラー: %v", err)
+		}
+		if err := writeFile(path, file.content); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// Blueprint構造を作成（今後実装）
-func createBlueprintStructure(config *types.ProjectConfig, data *TemplateData) error {
-	// とりあえず標準構造と同じにしておく
-	return createStandardStructure(config, data)
+// プロジェクトの作成先ディレクトリ（Path が指定されていればその配下）
+func projectDir(config *types.ProjectConfig) string {
+	return filepath.Join(config.Path, config.Name)
 }
 
-// テンプレートを処理
-func processTemplate(templateStr string, data *TemplateData) string {
-	tmpl, err := template.New("flask").Parse(templateStr)
-	if err != nil {
-		return templateStr // エラーの場合は元のテンプレートを返す
+// テンプレートデータを準備
+func prepareTemplateData(config *types.ProjectConfig) *TemplateData {
+	tool := packagingToolOf(config)
+	data := &TemplateData{
+		ProjectName: config.Name,
+		PackageName: naming.PackageName(config.Name),
+		Slug:        naming.Slug(config.Name),
+		Title:       naming.Title(config.Name),
+		AppType:     config.Type,
+		Structure:   config.Structure,
+		Packaging:   packagingOf(config),
+		Python:      config.Python,
+		MinPython:   python.Minimum(config.Python),
+
+		InstallCommands: tool.install,
+		RunPrefix:       tool.runPrefix,
+
+		Features:        make(map[string]bool),
+		FlashCategories: flashCategories,
+	}
+
+	// 無効な機能も .Features.<ID> で参照できるように false で登録する
+	// （未登録の ID を参照した場合は missingkey=error でエラーになる）
+	for _, feature := range features.All() {
+		data.Features[feature.ID()] = false
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return templateStr // エラーの場合は元のテンプレートを返す
+	// 依存パッケージ（共通 → 機能 → アプリタイプの順）
+	data.Dependencies = append(data.Dependencies, baseDependencies...)
+	for _, feature := range features.Enabled(config.Features) {
+		data.Features[feature.ID()] = true
+		data.enabledFeatures = append(data.enabledFeatures, feature.ID())
+		data.Dependencies = append(data.Dependencies, feature.Requirements()...)
+		if readme := feature.Readme(); readme != "" {
+			data.FeatureReadme = append(data.FeatureReadme, readme)
+		}
 	}
+	data.Dependencies = append(data.Dependencies, appTypeDependencies[config.Type]...)
 
-	return buf.String()
+	return data
 }
 
 // ファイルに内容を書き込む
@@ -238,4 +478,4 @@ func writeFile(path, content string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
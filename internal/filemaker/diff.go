@@ -0,0 +1,97 @@
+package filemaker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 差分の前後に表示する行数
+const diffContext = 3
+
+// 差分の1行分の操作
+type diffLine struct {
+	op   byte // ' ' 共通, '-' 削除, '+' 追加
+	text string
+}
+
+// 2つのテキストの行単位の差分を unified 形式で返す
+func unifiedDiff(oldText, newText, oldName, newName string) string {
+	lines := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	// 変更行の前後 diffContext 行だけを表示
+	show := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		for j := max(0, i-diffContext); j <= min(len(lines)-1, i+diffContext); j++ {
+			show[j] = true
+		}
+	}
+
+	for i, line := range lines {
+		if !show[i] {
+			continue
+		}
+		if i == 0 || !show[i-1] {
+			sb.WriteString("@@\n")
+		}
+		fmt.Fprintf(&sb, "%c %s\n", line.op, line.text)
+	}
+
+	return sb.String()
+}
+
+// 最長共通部分列 (LCS) から差分を求める
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] = a[i:] と b[j:] の LCS の長さ
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+
+	return lines
+}
+
+// テキストを行に分割（末尾の改行は無視）
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
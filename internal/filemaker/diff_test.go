@@ -0,0 +1,69 @@
+package filemaker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []diffLine
+	}{
+		{
+			name: "同じ内容",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "b"},
+			want: []diffLine{{' ', "a"}, {' ', "b"}},
+		},
+		{
+			name: "末尾への追加だけ",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "b", "c", "d"},
+			want: []diffLine{{' ', "a"}, {' ', "b"}, {'+', "c"}, {'+', "d"}},
+		},
+		{
+			name: "空のファイルへの追加",
+			a:    nil,
+			b:    []string{"a"},
+			want: []diffLine{{'+', "a"}},
+		},
+		{
+			name: "全て削除",
+			a:    []string{"a", "b"},
+			b:    nil,
+			want: []diffLine{{'-', "a"}, {'-', "b"}},
+		},
+		{
+			name: "途中の行の置き換え（削除を先に出す）",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "x", "c"},
+			want: []diffLine{{' ', "a"}, {'-', "b"}, {'+', "x"}, {' ', "c"}},
+		},
+		{
+			name: "先頭への挿入と末尾の削除",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"x", "a", "b"},
+			want: []diffLine{{'+', "x"}, {' ', "a"}, {' ', "b"}, {'-', "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	newText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n"
+	want := "--- a\n+++ b\n@@\n  8\n  9\n  10\n+ 11\n"
+
+	if got := unifiedDiff(oldText, newText, "a", "b"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -2,94 +2,204 @@ package filemaker
 
 import (
 	"fmt"
+	"github.com/KOU050223/flasgo/internal/features"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/python"
 	"github.com/KOU050223/flasgo/internal/ui"
+	"github.com/KOU050223/flasgo/internal/venv"
 	"github.com/KOU050223/flasgo/types"
 )
 
-func Generator() {
-	fmt.Println("✨ Flaskプロジェクトを作成します\n")
-	
+// プロジェクト生成時のオプション
+type GenerateOptions struct {
+	SaveAnswers string         // 回答ファイルの保存先（空なら保存しない）
+	DryRun      bool           // ファイルを書き込まずにプレビューのみ行う
+	ShowContent bool           // プレビュー時にファイルの内容も表示する
+	Conflict    ConflictPolicy // 作成先に既存ファイルがある場合の扱い
+	Pack        string         // テンプレートパック名（対話モードで収集した設定に適用する）
+	TemplateDir string         // テンプレートパックの検索先（同上）
+	Venv        bool           // 作成後に仮想環境を作成して依存関係をインストールする
+	FindLinks   string         // 仮想環境へのインストールに使うローカルの wheel ディレクトリ
+}
+
+// 対話モードでプロジェクト生成
+func Generator(opts GenerateOptions) error {
+	fmt.Print("✨ Flaskプロジェクトを作成します\n\n")
+
 	// プロジェクト設定を収集
 	config := collectProjectConfig()
-	
-	fmt.Printf("\n📁 プロジェクトを作成中...\n")
-	
-	// プロジェクト作成（実装予定）
-	err := createProject(config)
-	if err != nil {
-		fmt.Printf("❌ エラー: %v\n", err)
-		return
+	config.Pack = opts.Pack
+	config.TemplateDir = opts.TemplateDir
+	resolveCurrentDir(config)
+
+	if err := validateConfig(config); err != nil {
+		return err
 	}
-	
-	fmt.Printf("✅ %s プロジェクトが作成されました！\n", config.Name)
-	printNextSteps(config)
+
+	// 仮想環境を作成できる管理ツールなら作成するか確認する
+	if !opts.Venv && !opts.DryRun && venv.Supports(packagingOf(config)) == nil {
+		opts.Venv = ui.PromptConfirm("仮想環境を作成して依存関係をインストールしますか？")
+	}
+	if opts.Venv {
+		if err := venv.Supports(packagingOf(config)); err != nil {
+			return err
+		}
+	}
+
+	return generate(config, opts)
+}
+
+// 対話モードで入力されたプロジェクト名を検証
+// "." は作成先ディレクトリの名前に置き換えてから検証する
+func validateNameInput(name string) error {
+	if name == "." {
+		return nil
+	}
+	return naming.Validate(name)
 }
 
 // プロジェクト設定を対話的に収集
 func collectProjectConfig() *types.ProjectConfig {
 	config := &types.ProjectConfig{}
-	
+
 	// プロジェクト名
-	config.Name = ui.PromptText("プロジェクト名", "myflaskapp")
-	
+	config.Name = ui.PromptValidText("プロジェクト名", "myflaskapp", validateNameInput)
+
 	// アプリタイプ選択
 	appOptions := make([]ui.Option, len(types.AppTypes))
 	for i, appType := range types.AppTypes {
 		appOptions[i] = ui.Option{Label: appType.Label, Value: appType.Value}
 	}
 	config.Type = ui.PromptSelect("どのタイプのFlaskアプリを作成しますか？", appOptions)
-	
+
 	// プロジェクト構造選択
 	structOptions := make([]ui.Option, len(types.ProjectStructures))
 	for i, structure := range types.ProjectStructures {
 		structOptions[i] = ui.Option{Label: structure.Label, Value: structure.Value}
 	}
 	config.Structure = ui.PromptSelect("プロジェクト構造を選択してください", structOptions)
-	
+
 	// 追加機能選択
-	featureOptions := make([]ui.Option, len(types.AdditionalFeatures))
-	for i, feature := range types.AdditionalFeatures {
-		featureOptions[i] = ui.Option{Label: feature.Label, Value: feature.Value}
+	featureOptions := make([]ui.Option, len(features.All()))
+	for i, feature := range features.All() {
+		featureOptions[i] = ui.Option{Label: feature.Label(), Value: feature.ID()}
 	}
 	config.Features = ui.PromptMultiSelect("追加機能を選択してください", featureOptions)
-	
+
+	// 依存関係の管理ツール選択
+	packagingOptions := make([]ui.Option, len(types.PackagingTools))
+	for i, tool := range types.PackagingTools {
+		packagingOptions[i] = ui.Option{Label: tool.Label, Value: tool.Value}
+	}
+	config.Packaging = ui.PromptSelect("依存関係の管理ツールを選択してください", packagingOptions)
+
+	// Python の最小バージョン
+	config.Python = ui.PromptValidText("Python の最小バージョン（空欄なら指定しない）", "", python.ValidatePin)
+
 	return config
 }
 
-// デフォルト設定でプロジェクト生成（非対話モード）
-func GenerateWithDefaults(projectName string) {
-	fmt.Printf("✨ Flaskプロジェクト '%s' をデフォルト設定で作成します\n\n", projectName)
-	
-	// デフォルト設定
-	config := &types.ProjectConfig{
-		Name:      projectName,
-		Type:      "webapp",  // デフォルトはWebアプリ
-		Structure: "standard", // デフォルトは標準構造
-		Features:  []string{"env"}, // デフォルトは環境変数管理のみ
-	}
-	
+// 指定された設定でプロジェクト生成（非対話モード）
+func GenerateWithConfig(config *types.ProjectConfig, opts GenerateOptions) error {
+	fmt.Printf("✨ Flaskプロジェクト '%s' を作成します\n\n", config.Name)
+
 	fmt.Printf("設定:\n")
-	fmt.Printf("  タイプ: %s (Webアプリ)\n", config.Type)
-	fmt.Printf("  構造: %s (標準構造)\n", config.Structure)
+	fmt.Printf("  タイプ: %s (%s)\n", config.Type, optionLabel(types.AppTypes, config.Type))
+	fmt.Printf("  構造: %s (%s)\n", config.Structure, optionLabel(types.ProjectStructures, config.Structure))
 	fmt.Printf("  機能: %v\n", config.Features)
-	
+	fmt.Printf("  管理ツール: %s\n", optionLabel(types.PackagingTools, packagingOf(config)))
+	if config.Python != "" {
+		fmt.Printf("  Python: %s 以上\n", config.Python)
+	}
+	if config.Path != "" {
+		fmt.Printf("  作成先: %s\n", config.Path)
+	}
+	if config.Pack != "" || config.TemplateDir != "" {
+		fmt.Printf("  テンプレート: %s\n", packLabel(config))
+	}
+
+	return generate(config, opts)
+}
+
+// 使用するテンプレートパックの表示名
+func packLabel(config *types.ProjectConfig) string {
+	if config.Pack == "" {
+		return config.TemplateDir
+	}
+	if config.TemplateDir == "" {
+		return config.Pack
+	}
+	return fmt.Sprintf("%s (%s)", config.Pack, config.TemplateDir)
+}
+
+// 回答の保存・プレビュー・作成を行う
+func generate(config *types.ProjectConfig, opts GenerateOptions) error {
+	if opts.SaveAnswers != "" {
+		if err := saveAnswers(opts.SaveAnswers, config); err != nil {
+			return err
+		}
+	}
+
+	if opts.DryRun {
+		return previewProject(config, opts.ShowContent)
+	}
+
+	// 仮想環境を作成する場合は必要なバージョンの Python がなければ作成しない
+	// （それ以外は Python のない環境でも雛形だけ作成できるように警告にとどめる。
+	// poetry・uv は Python を自身で用意するので探さない）
+	var interpreter *python.Interpreter
+	if opts.Venv || packagingToolOf(config).runPrefix == "" {
+		var err error
+		if interpreter, err = python.Find(python.Minimum(config.Python)); err != nil {
+			if opts.Venv {
+				return err
+			}
+			fmt.Printf("⚠️  %v\n", err)
+		}
+	}
+
 	fmt.Printf("\n📁 プロジェクトを作成中...\n")
-	
+
 	// プロジェクト作成
-	err := createProject(config)
-	if err != nil {
-		fmt.Printf("❌ エラー: %v\n", err)
-		return
+	if err := createProject(config, opts.Conflict); err != nil {
+		return err
 	}
-	
+
 	fmt.Printf("✅ %s プロジェクトが作成されました！\n", config.Name)
-	printNextSteps(config)
+
+	if opts.Venv {
+		fmt.Println()
+		venvOpts := venv.Options{Dir: projectDir(config), Path: venv.DefaultPath, FindLinks: opts.FindLinks, Python: config.Python}
+		if err := venv.Setup(venvOpts); err != nil {
+			return fmt.Errorf("%v（プロジェクトで 'flasgo venv' を実行するとやり直せます）", err)
+		}
+	}
+
+	printNextSteps(config, interpreter, opts.Venv)
+	return nil
 }
 
-// 次のステップを表示
-func printNextSteps(config *types.ProjectConfig) {
+// 次のステップを表示（withVenv は仮想環境を作成済みの場合）
+// 仮想環境は見つかったインタープリタ（見つからなければ python3）で作成する手順を表示する
+func printNextSteps(config *types.ProjectConfig, interpreter *python.Interpreter, withVenv bool) {
 	fmt.Printf("\n次のステップ:\n")
-	fmt.Printf("  cd %s\n", config.Name)
-	fmt.Printf("  pip install -r requirements.txt\n")
-	fmt.Printf("  flask run\n")
+	fmt.Printf("  cd %s\n", projectDir(config))
+	if withVenv {
+		fmt.Printf("  %s\n", venv.ActivateCommand(venv.DefaultPath))
+		fmt.Printf("  flask run\n")
+		return
+	}
+	tool := packagingToolOf(config)
+	if tool.runPrefix == "" {
+		if interpreter != nil {
+			fmt.Printf("  %s -m venv %s  # Python %s\n", interpreter.Command, venv.DefaultPath, interpreter.Version)
+		} else {
+			fmt.Printf("  python3 -m venv %s\n", venv.DefaultPath)
+		}
+		fmt.Printf("  %s\n", venv.ActivateCommand(venv.DefaultPath))
+	}
+	for _, command := range tool.install {
+		fmt.Printf("  %s\n", command)
+	}
+	fmt.Printf("  %sflask run\n", tool.runPrefix)
 }
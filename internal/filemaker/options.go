@@ -0,0 +1,281 @@
+package filemaker
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/cli"
+	"github.com/KOU050223/flasgo/internal/features"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/python"
+	"github.com/KOU050223/flasgo/internal/templates"
+	"github.com/KOU050223/flasgo/internal/venv"
+	"github.com/KOU050223/flasgo/types"
+)
+
+// create コマンドの引数を解析してプロジェクトを作成
+//
+//	flasgo create                          対話モード
+//	flasgo create <name> [options]         非対話モード
+//	flasgo create --from project.yaml      回答ファイルから作成
+//	flasgo create <name> --pack acme       テンプレートパックを使って作成
+//	flasgo create <name> --venv            作成後に仮想環境を準備
+func Create(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	appType := fs.String("type", "webapp", "アプリタイプ ("+optionValues(types.AppTypes)+")")
+	structure := fs.String("structure", "standard", "プロジェクト構造 ("+optionValues(types.ProjectStructures)+")")
+	featureList := fs.String("features", "env", "追加機能をカンマ区切りで指定 ("+features.IDs()+")")
+	packaging := fs.String("packaging", defaultPackaging, "依存関係の管理ツール ("+optionValues(types.PackagingTools)+")")
+	dir := fs.String("dir", "", "プロジェクトの作成先ディレクトリ")
+	pythonVersion := fs.String("python", "", "Python の最小バージョン (例: 3.11、.python-version に記録する)")
+	from := fs.String("from", "", "回答ファイル (YAML / JSON) から設定を読み込む")
+	saveAnswersPath := fs.String("save-answers", "", "選択した設定を回答ファイル (YAML / JSON) に保存する")
+	dryRun := fs.Bool("dry-run", false, "ファイルを書き込まずに作成される内容を表示する")
+	showContent := fs.Bool("show-content", false, "ドライラン時に各ファイルの内容も表示する（--dry-run を含む）")
+	force := fs.Bool("force", false, "作成先の既存ファイルを全て上書きする")
+	skipExisting := fs.Bool("skip-existing", false, "作成先の既存ファイルは上書きせずに残す")
+	pack := fs.String("pack", "", "テンプレートパック名 (~/.config/flasgo/templates/<pack> を使用)")
+	templateDir := fs.String("template-dir", "", "テンプレートパックの検索先（--pack 省略時はこのディレクトリをパックとして使用）")
+	createVenv := fs.Bool("venv", false, "作成後に仮想環境を作成して依存関係をインストールする")
+	findLinks := fs.String("find-links", "", "--venv で wheel を置いたディレクトリからオフラインでインストールする")
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if len(positional) > 1 {
+		return fmt.Errorf("引数が多すぎます: %s", strings.Join(positional[1:], " "))
+	}
+
+	policy, err := conflictPolicy(*force, *skipExisting)
+	if err != nil {
+		return err
+	}
+
+	// 別のディレクトリから add しても同じパックを使えるように絶対パスにする
+	if *templateDir != "" {
+		if *templateDir, err = filepath.Abs(*templateDir); err != nil {
+			return fmt.Errorf("テンプレートディレクトリの解決に失敗: %v", err)
+		}
+	}
+
+	opts := GenerateOptions{
+		SaveAnswers: *saveAnswersPath,
+		DryRun:      *dryRun || *showContent,
+		ShowContent: *showContent,
+		Conflict:    policy,
+		Pack:        *pack,
+		TemplateDir: *templateDir,
+		Venv:        *createVenv,
+		FindLinks:   *findLinks,
+	}
+
+	// 明示的に指定されたオプション
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	// プロジェクト名も回答ファイルも指定されていない場合は対話モード
+	if len(positional) == 0 && *from == "" {
+		for name := range setFlags {
+			if !generateOptionFlags[name] {
+				return fmt.Errorf("プロジェクト名を指定してください")
+			}
+		}
+		return Generator(opts)
+	}
+
+	// 回答ファイルの値をベースに、明示的なオプションで上書きする
+	config := &types.ProjectConfig{
+		Type:        *appType,
+		Structure:   *structure,
+		Features:    parseFeatures(*featureList),
+		Packaging:   *packaging,
+		Path:        *dir,
+		Python:      *pythonVersion,
+		Pack:        *pack,
+		TemplateDir: *templateDir,
+	}
+	if *from != "" {
+		loaded, err := loadAnswers(*from)
+		if err != nil {
+			return err
+		}
+		config = mergeAnswers(loaded, config, setFlags)
+	}
+	if len(positional) > 0 {
+		config.Name = positional[0]
+	}
+	resolveCurrentDir(config)
+
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+
+	if opts.Venv {
+		if err := venv.Supports(packagingOf(config)); err != nil {
+			return err
+		}
+	}
+
+	return GenerateWithConfig(config, opts)
+}
+
+// 対話モードでも指定できるオプション
+var generateOptionFlags = map[string]bool{
+	"save-answers":  true,
+	"dry-run":       true,
+	"show-content":  true,
+	"force":         true,
+	"skip-existing": true,
+	"pack":          true,
+	"template-dir":  true,
+	"venv":          true,
+	"find-links":    true,
+}
+
+// プロジェクト名に "." が指定された場合は作成先ディレクトリそのものに生成する
+// （プロジェクト名はディレクトリ名から決める）
+func resolveCurrentDir(config *types.ProjectConfig) {
+	if config.Name != "." {
+		return
+	}
+	abs, err := filepath.Abs(projectDir(config))
+	if err != nil {
+		return
+	}
+	config.Name = filepath.Base(abs)
+	config.Path = filepath.Dir(abs)
+}
+
+// 回答ファイルの設定にオプションの値を反映する
+// 回答ファイルで省略された項目にはオプションのデフォルト値を使う
+func mergeAnswers(loaded, options *types.ProjectConfig, setFlags map[string]bool) *types.ProjectConfig {
+	config := *loaded
+	if setFlags["type"] || config.Type == "" {
+		config.Type = options.Type
+	}
+	if setFlags["structure"] || config.Structure == "" {
+		config.Structure = options.Structure
+	}
+	if setFlags["features"] || config.Features == nil {
+		config.Features = options.Features
+	}
+	if setFlags["packaging"] || config.Packaging == "" {
+		config.Packaging = options.Packaging
+	}
+	if setFlags["python"] {
+		config.Python = options.Python
+	}
+	if setFlags["dir"] {
+		config.Path = options.Path
+	}
+	if setFlags["pack"] {
+		config.Pack = options.Pack
+	}
+	if setFlags["template-dir"] {
+		config.TemplateDir = options.TemplateDir
+	}
+	return &config
+}
+
+// --force / --skip-existing から衝突時の扱いを決める
+func conflictPolicy(force, skipExisting bool) (ConflictPolicy, error) {
+	switch {
+	case force && skipExisting:
+		return ConflictAsk, fmt.Errorf("--force と --skip-existing は同時に指定できません")
+	case force:
+		return ConflictOverwrite, nil
+	case skipExisting:
+		return ConflictSkip, nil
+	default:
+		return ConflictAsk, nil
+	}
+}
+
+// カンマ区切りの機能リストを分解（空要素と重複は除く）
+func parseFeatures(value string) []string {
+	ids := []string{}
+	seen := make(map[string]bool)
+	for _, feature := range strings.Split(value, ",") {
+		feature = strings.TrimSpace(feature)
+		if feature == "" || seen[feature] {
+			continue
+		}
+		seen[feature] = true
+		ids = append(ids, feature)
+	}
+	return ids
+}
+
+// 設定値が定義済みの選択肢に含まれているか検証
+func validateConfig(config *types.ProjectConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("プロジェクト名を指定してください")
+	}
+	if err := naming.Validate(config.Name); err != nil {
+		return err
+	}
+	if !hasOption(types.AppTypes, config.Type) {
+		return fmt.Errorf("不明なアプリタイプ: %s (%s から選択してください)", config.Type, optionValues(types.AppTypes))
+	}
+	if !hasOption(types.ProjectStructures, config.Structure) {
+		return fmt.Errorf("不明なプロジェクト構造: %s (%s から選択してください)", config.Structure, optionValues(types.ProjectStructures))
+	}
+	for _, feature := range config.Features {
+		if _, ok := features.Get(feature); !ok {
+			return fmt.Errorf("不明な機能: %s (%s から選択してください)", feature, features.IDs())
+		}
+	}
+	if !hasOption(types.PackagingTools, packagingOf(config)) {
+		return fmt.Errorf("不明な管理ツール: %s (%s から選択してください)", config.Packaging, optionValues(types.PackagingTools))
+	}
+	if err := python.ValidatePin(config.Python); err != nil {
+		return err
+	}
+	if _, err := templates.FindPack(config.Pack, config.TemplateDir); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 選択肢の定義（types.AppTypes など）
+type optionList = []struct {
+	Value string
+	Label string
+}
+
+// 選択肢に値が含まれているか
+func hasOption(options optionList, value string) bool {
+	for _, option := range options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// 選択肢の値をカンマ区切りで列挙
+func optionValues(options optionList) string {
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = option.Value
+	}
+	return strings.Join(values, ", ")
+}
+
+// 値に対応するラベルを取得
+func optionLabel(options optionList, value string) string {
+	for _, option := range options {
+		if option.Value == value {
+			return option.Label
+		}
+	}
+	return value
+}
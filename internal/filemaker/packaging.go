@@ -0,0 +1,48 @@
+package filemaker
+
+import (
+	"github.com/KOU050223/flasgo/types"
+)
+
+// 依存関係の管理ツールを省略した場合（以前のマニフェストも含む）に使うツール
+const defaultPackaging = "pip"
+
+// 依存関係の管理ツールごとの手順
+type packagingTool struct {
+	install   []string // 依存関係のインストール手順
+	runPrefix string   // 仮想環境内でコマンドを実行するための接頭辞（ツールが仮想環境を管理する場合）
+}
+
+var packagingTools = map[string]packagingTool{
+	"pip": {
+		install: []string{"pip install -r requirements.txt"},
+	},
+	"pip-tools": {
+		install: []string{
+			"pip install pip-tools",
+			"pip-compile -o requirements.txt pyproject.toml",
+			"pip-sync requirements.txt",
+		},
+	},
+	"poetry": {
+		install:   []string{"poetry install"},
+		runPrefix: "poetry run ",
+	},
+	"uv": {
+		install:   []string{"uv sync"},
+		runPrefix: "uv run ",
+	},
+}
+
+// 設定の依存関係の管理ツール
+func packagingOf(config *types.ProjectConfig) string {
+	if config.Packaging == "" {
+		return defaultPackaging
+	}
+	return config.Packaging
+}
+
+// 設定の依存関係の管理ツールの手順
+func packagingToolOf(config *types.ProjectConfig) packagingTool {
+	return packagingTools[packagingOf(config)]
+}
@@ -0,0 +1,126 @@
+package filemaker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/KOU050223/flasgo/types"
+)
+
+// ファイルを書き込まずに作成されるディレクトリ構造を表示（--dry-run）
+func previewProject(config *types.ProjectConfig, showContent bool) error {
+	root := projectDir(config)
+
+	plan, err := buildProjectPlan(config)
+	if err != nil {
+		return err
+	}
+	if err := planManifest(plan, root, config, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🔍 ドライラン: 以下のファイルが作成されます（ディスクには書き込みません）\n\n")
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		fmt.Printf("⚠️  プロジェクトディレクトリ '%s' は既に存在します\n\n", root)
+	}
+
+	printPlanTree(root, plan)
+
+	if showContent {
+		for _, file := range plan.files {
+			fmt.Printf("\n───── %s (%s) ─────\n", filepath.ToSlash(file.path), formatSize(len(file.content)))
+			fmt.Print(file.content)
+			if !strings.HasSuffix(file.content, "\n") {
+				fmt.Println()
+			}
+		}
+	}
+
+	return nil
+}
+
+// ツリー表示用のノード
+type treeNode struct {
+	children map[string]*treeNode
+	isFile   bool
+	size     int
+}
+
+// ディレクトリツリーとファイルサイズを表示
+func printPlanTree(root string, plan *projectPlan) {
+	tree := &treeNode{children: map[string]*treeNode{}}
+
+	// パスを分解してツリーに登録
+	insert := func(path string) *treeNode {
+		node := tree
+		for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+		return node
+	}
+
+	for _, dir := range plan.dirs {
+		insert(dir)
+	}
+	totalSize := 0
+	for _, file := range plan.files {
+		node := insert(file.path)
+		node.isFile = true
+		node.size = len(file.content)
+		totalSize += node.size
+	}
+
+	fmt.Printf("%s/\n", filepath.ToSlash(root))
+	dirCount := printTreeNode(tree, "")
+
+	fmt.Printf("\n%d ディレクトリ, %d ファイル, 合計 %s\n", dirCount, len(plan.files), formatSize(totalSize))
+}
+
+// ノードの子要素を再帰的に表示（ディレクトリ → ファイルの順）し、ディレクトリ数を返す
+func printTreeNode(node *treeNode, indent string) int {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := node.children[names[i]], node.children[names[j]]
+		if a.isFile != b.isFile {
+			return !a.isFile
+		}
+		return names[i] < names[j]
+	})
+
+	dirCount := 0
+	for i, name := range names {
+		child := node.children[name]
+		branch, nextIndent := "├── ", indent+"│   "
+		if i == len(names)-1 {
+			branch, nextIndent = "└── ", indent+"    "
+		}
+
+		if child.isFile {
+			fmt.Printf("%s%s%s (%s)\n", indent, branch, name, formatSize(child.size))
+			continue
+		}
+
+		fmt.Printf("%s%s%s/\n", indent, branch, name)
+		dirCount += 1 + printTreeNode(child, nextIndent)
+	}
+	return dirCount
+}
+
+// バイト数を読みやすい形式に変換
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
@@ -0,0 +1,174 @@
+package filemaker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/KOU050223/flasgo/internal/features"
+	"github.com/KOU050223/flasgo/internal/templates"
+)
+
+// テンプレートツリーのうち条件に合うレイヤーのファイルを一覧に追加
+// 後のレイヤー・後のツリーに同じパスのファイルがあれば上書きする
+// テンプレートの解析・実行に失敗した場合はファイル名と行番号を含むエラーを返す
+func planTemplateTree(plan *projectPlan, trees []templates.Tree, layers []templates.Layer, data *TemplateData) error {
+	// TemplateData にないフィールド・キーを参照した場合はエラーにする
+	// slot は renderTemplate で処理中のテンプレートに結び付けるため、ここでは解析用に登録するだけ
+	partials := template.New(templates.PartialsDir).Option("missingkey=error").Funcs(template.FuncMap{
+		"slot": func(string) (string, error) { return "", nil },
+	})
+	dirs := []string{templates.PartialsDir}
+	for _, feature := range features.All() {
+		dirs = append(dirs, templates.FeaturePartialsDir(feature.ID()))
+	}
+	for _, tree := range trees {
+		for _, dir := range dirs {
+			if err := parsePartials(partials, tree, dir); err != nil {
+				return err
+			}
+		}
+	}
+
+	index := make(map[string]int) // 出力先のパス → plan.files の位置
+	for _, layer := range layers {
+		enabled, err := layerEnabled(layer, data)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			continue
+		}
+
+		for _, tree := range trees {
+			if err := planLayer(plan, index, tree, layer, partials, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ツリー内のレイヤーのファイルを一覧に追加（index は出力先のパス → plan.files の位置）
+func planLayer(plan *projectPlan, index map[string]int, tree templates.Tree, layer templates.Layer, partials *template.Template, data *TemplateData) error {
+	return fs.WalkDir(tree.FS, layer.Dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == layer.Dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll // このツリーにはレイヤーのディレクトリがない
+			}
+			return fmt.Errorf("テンプレートの読み込みに失敗 (%s): %v", tree.Path(name), err)
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		src, err := fs.ReadFile(tree.FS, name)
+		if err != nil {
+			return fmt.Errorf("テンプレートの読み込みに失敗 (%s): %v", tree.Path(name), err)
+		}
+
+		content := string(src)
+		if templates.IsTemplate(name) {
+			if content, err = renderTemplate(partials, tree.Path(name), content, data); err != nil {
+				return err
+			}
+		}
+
+		path := filepath.FromSlash(templates.OutputPath(strings.TrimPrefix(name, layer.Dir+"/")))
+		if i, ok := index[path]; ok {
+			plan.files[i].content = content
+			return nil
+		}
+		index[path] = len(plan.files)
+		plan.addFile(path, content)
+		return nil
+	})
+}
+
+// レイヤーの条件（when）を評価する（条件がなければ常に有効）
+func layerEnabled(layer templates.Layer, data *TemplateData) (bool, error) {
+	if layer.When == "" {
+		return true, nil
+	}
+
+	tmpl, err := template.New(layer.Dir).Option("missingkey=error").Parse("{{if " + layer.When + "}}true{{end}}")
+	if err != nil {
+		return false, fmt.Errorf("レイヤー '%s' の条件が不正です: %v", layer.Dir, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return false, fmt.Errorf("レイヤー '%s' の条件の評価に失敗: %v", layer.Dir, err)
+	}
+	return buf.String() == "true", nil
+}
+
+// ツリーの共通部品（dir 以下の {{define}}）を読み込む
+// 既に読み込んだ部品と同じ名前で定義した場合は置き換える
+func parsePartials(partials *template.Template, tree templates.Tree, dir string) error {
+	return fs.WalkDir(tree.FS, dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if name == dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return fmt.Errorf("共通テンプレートの読み込みに失敗 (%s): %v", tree.Path(name), err)
+		}
+		if d.IsDir() || !templates.IsTemplate(name) {
+			return nil
+		}
+
+		src, err := fs.ReadFile(tree.FS, name)
+		if err != nil {
+			return fmt.Errorf("共通テンプレートの読み込みに失敗 (%s): %v", tree.Path(name), err)
+		}
+		if _, err := partials.New(tree.Path(name)).Delims(templates.Delims(name)).Parse(string(src)); err != nil {
+			return fmt.Errorf("共通テンプレートの解析に失敗: %v", err)
+		}
+		return nil
+	})
+}
+
+// 共通部品を使えるようにしてテンプレートを処理（区切り文字はファイル名から決める）
+// エラーには "template: <ファイル名>:<行>:" の形式で位置が含まれる
+func renderTemplate(partials *template.Template, name, templateStr string, data *TemplateData) (string, error) {
+	base, err := partials.Clone()
+	if err != nil {
+		return "", fmt.Errorf("テンプレートの準備に失敗 (%s): %v", name, err)
+	}
+	base.Funcs(template.FuncMap{"slot": slotFunc(base, data)})
+
+	tmpl, err := base.New(name).Delims(templates.Delims(name)).Parse(templateStr)
+	if err != nil {
+		return "", fmt.Errorf("テンプレートの解析に失敗: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("テンプレートの処理に失敗: %v", err)
+	}
+
+	return buf.String(), nil
+}
+
+// {{slot "名前"}}: 有効な機能が定義した "<機能>.<名前>" の部品を機能の登録順に処理して連結する
+// 機能ごとのコード（import・初期化・設定・README の記述など）をアプリのテンプレートに差し込むために使う
+func slotFunc(tmpl *template.Template, data *TemplateData) func(string) (string, error) {
+	return func(name string) (string, error) {
+		var buf bytes.Buffer
+		for _, id := range data.enabledFeatures {
+			partial := tmpl.Lookup(id + "." + name)
+			if partial == nil {
+				continue
+			}
+			if err := partial.Execute(&buf, data); err != nil {
+				return "", err
+			}
+		}
+		return buf.String(), nil
+	}
+}
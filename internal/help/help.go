@@ -2,14 +2,26 @@ package help
 
 import (
 	"fmt"
+
+	"github.com/KOU050223/flasgo/internal/features"
 	"github.com/KOU050223/flasgo/types"
 )
 
 func Help() {
 	fmt.Println("ヘルプ一覧を表示します")
 	commands := []types.Command{
-		types.Command{"create", "flaskの標準的なフォルダ・ファイルを生成します"},
-		types.Command{"help", "コマンド一覧を表示します"},
+		{Name: "create", Description: "flaskの標準的なフォルダ・ファイルを生成します"},
+		{Name: "add", Description: "既存のプロジェクトに追加機能 (" + features.IDs() + ") を有効化します"},
+		{Name: "python", Description: "インストールされている Python とバージョンを一覧表示します (--python で最小バージョンを指定)"},
+		{Name: "venv", Description: "仮想環境を作成して依存関係をインストールします (--find-links でオフラインインストール)"},
+		{Name: "run", Description: "仮想環境と .env を使ってアプリを起動します (--host, --port, --debug)"},
+		{Name: "db", Description: "マイグレーションを実行します (init, migrate -m, upgrade, downgrade, history)"},
+		{Name: "make:model", Description: "SQLAlchemy のモデルを生成します (例: make:model Article title:string:required author:belongs_to:User)"},
+		{Name: "make:seeder", Description: "シーダーを作成して flask seed の実行順に登録します (例: make:seeder Users)"},
+		{Name: "db:seed", Description: "シーダーで初期データを投入します (名前を省略すると全て実行、--truncate で削除してから投入)"},
+		{Name: "db:load", Description: "CSV・JSON・YAML のファイルからモデルにデータを投入します (例: db:load fixtures/users.csv --model User)"},
+		{Name: "doctor", Description: "Python・仮想環境・依存関係・.env を診断します"},
+		{Name: "help", Description: "コマンド一覧を表示します"},
 	}
 	for i, command := range commands {
 		fmt.Printf("%d. %s: %s\n", i+1, command.Name, command.Description)
@@ -0,0 +1,103 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KOU050223/flasgo/types"
+)
+
+// マニフェストのファイル名（プロジェクトルートに作成する）
+const FileName = ".flasgo.json"
+
+// プロジェクトがどのように生成されたかの記録
+type Manifest struct {
+	FlasgoVersion string              `json:"flasgo_version"` // 生成に使った flasgo のバージョン
+	Config        types.ProjectConfig `json:"config"`         // 生成時の設定
+	Templates     map[string]string   `json:"templates"`      // テンプレートセットごとのバージョン
+	Files         map[string]string   `json:"files"`          // 生成したファイルのパスとチェックサム
+}
+
+// ファイル内容のチェックサム
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// プロジェクトのマニフェストを読み込む
+func Load(root string) (*Manifest, error) {
+	path := filepath.Join(root, FileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("マニフェスト (%s) が見つかりません。flasgo で作成したプロジェクトのルートで実行してください", FileName)
+		}
+		return nil, fmt.Errorf("マニフェストの読み込みに失敗 (%s): %v", path, err)
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("マニフェストの解析に失敗 (%s): %v", path, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	// テンプレートディレクトリはプロジェクトからの相対パスで記録している
+	if dir := m.Config.TemplateDir; dir != "" && !filepath.IsAbs(dir) {
+		m.Config.TemplateDir = filepath.Join(root, filepath.FromSlash(dir))
+	}
+
+	return m, nil
+}
+
+// マニフェストに記録するテンプレートディレクトリ（プロジェクトルートからの相対パス）
+// 他の環境の clone でも同じパスで見つかるように絶対パスは記録しない
+func RelativeTemplateDir(root, dir string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("プロジェクトディレクトリの解決に失敗: %v", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("テンプレートディレクトリの解決に失敗: %v", err)
+	}
+	rel, err := filepath.Rel(absRoot, absDir)
+	if err != nil {
+		return "", fmt.Errorf("テンプレートディレクトリの解決に失敗: %v", err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// マニフェストを JSON に変換する
+func (m *Manifest) Marshal() (string, error) {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("マニフェストの生成に失敗: %v", err)
+	}
+	return string(content) + "\n", nil
+}
+
+// 生成したファイルを記録する（path はプロジェクトルートからの相対パス）
+func (m *Manifest) Record(path string, content []byte) {
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	m.Files[filepath.ToSlash(path)] = Checksum(content)
+}
+
+// 生成した時点の内容のままか（記録されていないファイルは false）
+func (m *Manifest) Unmodified(root, path string) bool {
+	recorded, ok := m.Files[filepath.ToSlash(path)]
+	if !ok {
+		return false
+	}
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		return false
+	}
+	return Checksum(content) == recorded
+}
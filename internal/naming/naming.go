@@ -0,0 +1,91 @@
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// プロジェクト名の最大長
+const maxLength = 64
+
+// プロジェクト名に使える文字（英字で始まり、英数字・ハイフン・アンダースコアのみ）
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// プロジェクト名を検証する
+// ディレクトリ名と Python のパッケージ名の両方に使えない名前はエラーにする
+func Validate(name string) error {
+	if name == "" {
+		return fmt.Errorf("プロジェクト名を入力してください")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("プロジェクト名 '%s' にパスは指定できません（作成先は --dir で指定してください）", name)
+	}
+	if len(name) > maxLength {
+		return fmt.Errorf("プロジェクト名は %d 文字以内で入力してください", maxLength)
+	}
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("プロジェクト名 '%s' は使えません（英字で始まり、英数字・ハイフン・アンダースコアのみ使えます）", name)
+	}
+
+	pkg := PackageName(name)
+	switch {
+	case pythonKeywords[pkg]:
+		return fmt.Errorf("プロジェクト名 '%s' は Python の予約語のため使えません", name)
+	case stdlibModules[pkg]:
+		return fmt.Errorf("プロジェクト名 '%s' は Python の標準ライブラリ '%s' と衝突するため使えません", name, pkg)
+	case dependencyPackages[pkg]:
+		return fmt.Errorf("プロジェクト名 '%s' はパッケージ '%s' と衝突するため使えません", name, pkg)
+	}
+	return nil
+}
+
+// Python のパッケージ名（例: My-App → my_app）
+func PackageName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "-", "_")
+}
+
+// URL やパッケージの配布名に使うスラッグ（例: My_App → my-app）
+func Slug(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// 画面に表示するタイトル（例: my_app → My App）
+func Title(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// Python の予約語か
+func IsKeyword(name string) bool {
+	return pythonKeywords[name]
+}
+
+// クラス名（例: blog_post → BlogPost、BlogPost はそのまま）
+func ClassName(name string) string {
+	return strings.ReplaceAll(Title(name), " ", "")
+}
+
+// スネークケース（例: BlogPost → blog_post）
+// Flask-SQLAlchemy がモデル名からテーブル名を決めるときと同じ規則で変換する
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || nextLower {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.TrimPrefix(b.String(), "_")
+}
@@ -0,0 +1,82 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// 生成するプロジェクトで使う Flask の最小バージョン
+const FlaskVersion = "2.3.0"
+
+// Flask のバージョンごとに必要な Python の最小バージョン（新しい順）
+var flaskRequirements = []struct {
+	Flask  string
+	Python string
+}{
+	{"3.1", "3.9"},
+	{"2.3", "3.8"},
+	{"2.1", "3.7"},
+	{"2.0", "3.6"},
+}
+
+// プロジェクトで使う Python のバージョンを記録するファイル（pyenv などが参照する）
+const VersionFile = ".python-version"
+
+// --python で指定できるバージョンの形式（3.X または 3.X.Y）
+var pinPattern = regexp.MustCompile(`^3\.\d+(\.\d+)?$`)
+
+// Flask のバージョンが必要とする Python の最小バージョン
+func FlaskRequires(flask string) string {
+	for _, requirement := range flaskRequirements {
+		if Compare(flask, requirement.Flask) >= 0 {
+			return requirement.Python
+		}
+	}
+	return flaskRequirements[len(flaskRequirements)-1].Python
+}
+
+// プロジェクトに必要な Python の最小バージョン（pin が指定されていれば pin）
+func Minimum(pin string) string {
+	if pin == "" {
+		return FlaskRequires(FlaskVersion)
+	}
+	return pin
+}
+
+// --python で指定されたバージョンを検証する
+func ValidatePin(pin string) error {
+	if pin == "" {
+		return nil
+	}
+	if !pinPattern.MatchString(pin) {
+		return fmt.Errorf("不正な Python のバージョン: %s (例: 3.11)", pin)
+	}
+	if required := FlaskRequires(FlaskVersion); Compare(pin, required) < 0 {
+		return fmt.Errorf("Flask %s には Python %s 以上が必要です（指定: %s）", FlaskVersion, required, pin)
+	}
+	return nil
+}
+
+// プロジェクトの .python-version に記録されたバージョン（なければ空文字）
+// pyenv の "system" や "pypy3.9" など 3.X[.Y] の形式でない指定は、警告を表示して指定がないものとして扱う
+func ReadVersionFile(root string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(root, VersionFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s の読み込みに失敗: %v", VersionFile, err)
+	}
+	pin := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+	if pin != "" && !pinPattern.MatchString(pin) {
+		fmt.Printf("⚠️  %s の '%s' は 3.X または 3.X.Y の形式ではないため無視します\n", VersionFile, pin)
+		return "", nil
+	}
+	if err := ValidatePin(pin); err != nil {
+		return "", fmt.Errorf("%s: %v", VersionFile, err)
+	}
+	return pin, nil
+}
@@ -0,0 +1,56 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadVersionFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string // nil ならファイルを作らない
+		want    string
+		wantErr string
+	}{
+		{name: "ファイルがない"},
+		{name: "3.X", content: ptr("3.11\n"), want: "3.11"},
+		{name: "3.X.Y", content: ptr("3.12.1\n"), want: "3.12.1"},
+		{name: "複数行は先頭だけ", content: ptr("3.11\n3.10\n"), want: "3.11"},
+		{name: "空のファイル", content: ptr("")},
+		{name: "system", content: ptr("system\n")},
+		{name: "pypy", content: ptr("pypy3.9\n")},
+		{name: "開発版", content: ptr("3.12-dev\n")},
+		{name: "Flask に対して古いバージョン", content: ptr("3.7\n"), wantErr: "Python 3.8 以上が必要です"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.content != nil {
+				if err := os.WriteFile(filepath.Join(root, VersionFile), []byte(*tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := ReadVersionFile(root)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,251 @@
+package python
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Python インタープリタ
+type Interpreter struct {
+	Command string // 実行するコマンド（PATH 上にあればコマンド名、それ以外はフルパス）
+	Path    string // 実行ファイルのパス
+	Version string // バージョン (例: 3.11.2)
+	Source  string // 見つけた場所 (PATH, pyenv)
+
+	executable string // 実体のパス（同じインタープリタの重複を除くために使う）
+}
+
+// python コマンド: 見つかったインタープリタとバージョンを一覧表示する
+//
+//	flasgo python [--dir DIR] [--python 3.X]
+func Run(args []string) error {
+	fs := flag.NewFlagSet("python", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ（.python-version を参照する）")
+	pin := fs.String("python", "", "Python の最小バージョン（省略時は .python-version）")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("引数が多すぎます: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if *pin == "" {
+		var err error
+		if *pin, err = ReadVersionFile(*dir); err != nil {
+			return err
+		}
+	}
+	if err := ValidatePin(*pin); err != nil {
+		return err
+	}
+	minimum := Minimum(*pin)
+
+	interpreters := Discover()
+	fmt.Printf("🔍 Python インタープリタ（Python %s 以上が必要）:\n", minimum)
+	selected, err := Select(interpreters, minimum)
+	for _, interpreter := range interpreters {
+		mark := " "
+		if selected != nil && selected.Path == interpreter.Path {
+			mark = "*"
+		}
+		note := ""
+		if Compare(interpreter.Version, minimum) < 0 {
+			note = "  ⚠️ バージョンが古いため使用できません"
+		}
+		fmt.Printf("  %s %-8s %s (%s)%s\n", mark, interpreter.Version, interpreter.Command, interpreter.Source, note)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ %s (Python %s) を使用します\n", selected.Command, selected.Version)
+	return nil
+}
+
+// PATH から探すインタープリタの名前（python3.X はこの後に新しい順で探す）
+var candidates = []string{"python3", "python"}
+
+// バージョンと実体のパスを取得するためのスクリプト
+const inspectScript = "import os, sys; print('%d.%d.%d' % sys.version_info[:3]); print(os.path.realpath(sys.executable))"
+
+// インストールされている Python 3 のインタープリタを探す
+// PATH の各ディレクトリ（pyenv の shims を含む）、pyenv でインストールしたバージョンの順に探し、
+// 同じインタープリタは最初に見つかったものだけを返す
+func Discover() []Interpreter {
+	return discover(nil)
+}
+
+// インタープリタを探す（done が真を返すインタープリタが見つかった時点で終了する）
+func discover(done func(Interpreter) bool) []Interpreter {
+	var found []Interpreter
+	seen := make(map[string]bool)
+	add := func(command, path, source string) bool {
+		interpreter, err := Inspect(path)
+		if err != nil || !strings.HasPrefix(interpreter.Version, "3.") || seen[interpreter.executable] {
+			return false
+		}
+		seen[interpreter.executable] = true
+		interpreter.Command = command
+		interpreter.Source = source
+		found = append(found, *interpreter)
+		return done != nil && done(*interpreter)
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		for _, name := range append(append([]string{}, candidates...), versionedNames(dir)...) {
+			path, err := exec.LookPath(filepath.Join(dir, name))
+			if err != nil {
+				continue
+			}
+			// PATH で先に見つかるものだけがコマンド名で実行できる
+			command := path
+			if first, err := exec.LookPath(name); err == nil && first == path {
+				command = name
+			}
+			if add(command, path, "PATH") {
+				return found
+			}
+		}
+	}
+
+	for _, path := range pyenvVersions() {
+		if add(path, path, "pyenv") {
+			return found
+		}
+	}
+	return found
+}
+
+// ディレクトリ内の python3.X の名前（新しい順）
+func versionedNames(dir string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "python3.*"))
+	var names []string
+	for _, match := range matches {
+		name := strings.TrimSuffix(filepath.Base(match), ".exe")
+		if _, err := parseVersion(strings.TrimPrefix(name, "python")); err == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return Compare(strings.TrimPrefix(names[i], "python"), strings.TrimPrefix(names[j], "python")) > 0
+	})
+	return names
+}
+
+// pyenv でインストールしたインタープリタ（新しい順）
+func pyenvVersions() []string {
+	root := os.Getenv("PYENV_ROOT")
+	if root == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		root = filepath.Join(home, ".pyenv")
+	}
+
+	binary := filepath.Join("bin", "python3")
+	if runtime.GOOS == "windows" {
+		binary = "python.exe"
+	}
+	matches, _ := filepath.Glob(filepath.Join(root, "versions", "*", binary))
+	sort.Slice(matches, func(i, j int) bool {
+		return Compare(versionDir(matches[i], binary), versionDir(matches[j], binary)) > 0
+	})
+	return matches
+}
+
+// pyenv のインタープリタのパスからバージョンのディレクトリ名を取り出す
+func versionDir(path, binary string) string {
+	return filepath.Base(strings.TrimSuffix(path, string(filepath.Separator)+binary))
+}
+
+// 最小バージョン以上のインタープリタを選ぶ（見つかった順で最初のもの）
+func Select(interpreters []Interpreter, minimum string) (*Interpreter, error) {
+	for i := range interpreters {
+		if Compare(interpreters[i].Version, minimum) >= 0 {
+			return &interpreters[i], nil
+		}
+	}
+
+	if len(interpreters) == 0 {
+		return nil, fmt.Errorf("Python 3 が見つかりません（Python %s 以上をインストールしてください）", minimum)
+	}
+	versions := make([]string, len(interpreters))
+	for i, interpreter := range interpreters {
+		versions[i] = interpreter.Version
+	}
+	return nil, fmt.Errorf("Python %s 以上が見つかりません（見つかったバージョン: %s）", minimum, strings.Join(versions, ", "))
+}
+
+// 最小バージョン以上のインタープリタを探す
+func Find(minimum string) (*Interpreter, error) {
+	found := discover(func(interpreter Interpreter) bool {
+		return Compare(interpreter.Version, minimum) >= 0
+	})
+	return Select(found, minimum)
+}
+
+// 指定したインタープリタのバージョンを調べる
+func Inspect(path string) (*Interpreter, error) {
+	output, err := exec.Command(path, "-c", inspectScript).Output()
+	if err != nil {
+		return nil, fmt.Errorf("Python のバージョンの取得に失敗 (%s): %v", path, err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("Python のバージョンの取得に失敗 (%s): 予期しない出力です", path)
+	}
+	return &Interpreter{
+		Command:    path,
+		Path:       path,
+		Version:    strings.TrimSpace(lines[0]),
+		executable: strings.TrimSpace(lines[1]),
+	}, nil
+}
+
+// バージョンを比較する（a < b なら負、a == b なら 0、a > b なら正）
+// 省略された要素は 0 とみなす（"3.11" と "3.11.0" は等しい）
+func Compare(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for len(va) < len(vb) {
+		va = append(va, 0)
+	}
+	for len(vb) < len(va) {
+		vb = append(vb, 0)
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] - vb[i]
+		}
+	}
+	return 0
+}
+
+// "3.11.2" のようなバージョンを数値に分解する
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("不正なバージョン: %s", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// .env を読み込んで "KEY=VALUE" の一覧を返す（ファイルがなければ空）
+// python-dotenv と同じく、"export " の接頭辞、引用符で囲んだ値、行末の " #" 以降のコメントを扱う
+func LoadDotenv(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(".env の読み込みに失敗: %v", err)
+	}
+
+	var env []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		env = append(env, key+"="+dotenvValue(strings.TrimSpace(value)))
+	}
+	return env, nil
+}
+
+// .env の値から引用符やコメントを取り除く
+func dotenvValue(value string) string {
+	if len(value) >= 2 {
+		if quote := value[0]; (quote == '"' || quote == '\'') && value[len(value)-1] == quote {
+			return value[1 : len(value)-1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// "KEY=VALUE" のキー
+func envKey(entry string) string {
+	key, _, _ := strings.Cut(entry, "=")
+	return key
+}
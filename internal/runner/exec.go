@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// 子プロセスに転送するシグナル
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// SIGINT を転送するまでの猶予
+// 端末で Ctrl+C を押した場合は子プロセスにも直接届くため、猶予内に終了しなければ転送する
+const interruptGrace = time.Second
+
+// コマンドを実行し、終了するまで受け取ったシグナルを転送する
+// シグナルで終了した場合はエラーにしない
+func Exec(cmd *exec.Cmd) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s の実行に失敗: %v", cmd.Path, err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	interrupted := false
+	var grace <-chan time.Time
+	for {
+		select {
+		case sig := <-signals:
+			interrupted = true
+			if sig == os.Interrupt && grace == nil {
+				grace = time.After(interruptGrace)
+				continue
+			}
+			_ = cmd.Process.Signal(sig)
+		case <-grace:
+			_ = cmd.Process.Signal(os.Interrupt)
+		case err := <-done:
+			if err != nil && !interrupted {
+				return fmt.Errorf("flask コマンドが失敗しました: %v", err)
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/KOU050223/flasgo/internal/manifest"
+	"github.com/KOU050223/flasgo/internal/venv"
+	"github.com/KOU050223/flasgo/types"
+)
+
+// 仮想環境を探すパス（--venv を省略した場合、先に見つかったものを使う）
+var venvCandidates = []string{venv.DefaultPath, ".venv"}
+
+// 仮想環境を自身で管理する管理ツールのコマンド（"uv run flask ..." のように実行する）
+var managedRunners = map[string]string{
+	"poetry": "poetry",
+	"uv":     "uv",
+}
+
+// Flask を実行するプロジェクト
+type Project struct {
+	Root      string // プロジェクトルート
+	Structure string // プロジェクト構造（マニフェストがなければファイル構成から判断する）
+	Packaging string // 依存関係の管理ツール（マニフェストがなければ空文字）
+	Venv      string // 仮想環境のパス（空なら venvCandidates から探す）
+
+	Config *types.ProjectConfig // 生成時の設定（マニフェストがなければ nil）
+}
+
+// プロジェクトを読み込む
+// flasgo で作成したプロジェクトはマニフェストの設定を、それ以外はファイル構成を使う
+func Load(dir string) (*Project, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトディレクトリの解決に失敗: %v", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("プロジェクトディレクトリが見つかりません: %s", dir)
+	}
+
+	p := &Project{Root: root}
+	if _, err := os.Stat(filepath.Join(root, manifest.FileName)); err == nil {
+		m, err := manifest.Load(root)
+		if err != nil {
+			return nil, err
+		}
+		p.Structure = m.Config.Structure
+		p.Packaging = m.Config.Packaging
+		p.Config = &m.Config
+		return p, nil
+	}
+
+	switch {
+	case p.exists("wsgi.py"):
+		p.Structure = "blueprint"
+	case p.exists("app.py"):
+		p.Structure = "standard"
+	default:
+		return nil, fmt.Errorf("Flask アプリ (app.py / wsgi.py) が見つかりません: %s", root)
+	}
+	return p, nil
+}
+
+// FLASK_APP に指定するファイル（Blueprint構造は wsgi.py、それ以外は app.py）
+func (p *Project) AppFile() string {
+	if p.Structure == "blueprint" {
+		return "wsgi.py"
+	}
+	return "app.py"
+}
+
+// flask コマンドを実行する exec.Cmd を作る
+// 管理ツールが仮想環境を管理する場合はそのツール経由で、それ以外は仮想環境の Python で実行する
+// 環境変数には .env の値（既に設定されているものを除く）と FLASK_APP を加える
+func (p *Project) Flask(args ...string) (*exec.Cmd, error) {
+	var cmd *exec.Cmd
+	if tool, ok := managedRunners[p.Packaging]; ok {
+		path, err := exec.LookPath(tool)
+		if err != nil {
+			return nil, fmt.Errorf("%s が見つかりません（PATH に %s をインストールしてください）", tool, tool)
+		}
+		cmd = exec.Command(path, append([]string{"run", "flask"}, args...)...)
+	} else {
+		executable, err := p.Python()
+		if err != nil {
+			return nil, err
+		}
+		cmd = exec.Command(executable, append([]string{"-m", "flask"}, args...)...)
+	}
+
+	env, err := p.environ()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Dir = p.Root
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// 仮想環境のディレクトリ
+func (p *Project) VenvDir() (string, error) {
+	candidates := venvCandidates
+	if p.Venv != "" {
+		candidates = []string{p.Venv}
+	}
+	for _, candidate := range candidates {
+		path := candidate
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(p.Root, path)
+		}
+		if _, err := os.Stat(venv.Python(path)); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("仮想環境が見つかりません。先に 'flasgo venv' を実行してください")
+}
+
+// 仮想環境の Python のパス
+func (p *Project) Python() (string, error) {
+	path, err := p.VenvDir()
+	if err != nil {
+		return "", err
+	}
+	return venv.Python(path), nil
+}
+
+// 機能が有効か（マニフェストがない場合は確認できないため true）
+func (p *Project) HasFeature(id string) bool {
+	if p.Config == nil {
+		return true
+	}
+	for _, feature := range p.Config.Features {
+		if feature == id {
+			return true
+		}
+	}
+	return false
+}
+
+// 機能が有効でなければエラーを返す（機能を前提とするコマンドで使う）
+func (p *Project) RequireFeature(id string) error {
+	if !p.HasFeature(id) {
+		return fmt.Errorf("%s 機能が有効ではありません。先に 'flasgo add %s' を実行してください", id, id)
+	}
+	return nil
+}
+
+// 仮想環境を自身で管理する管理ツールを使うか
+func (p *Project) Managed() bool {
+	_, ok := managedRunners[p.Packaging]
+	return ok
+}
+
+// flask コマンドに渡す環境変数
+// 優先順位: 実行時の環境変数 > .env > flasgo が決める値（FLASK_APP）
+func (p *Project) environ() ([]string, error) {
+	env := os.Environ()
+	set := make(map[string]bool)
+	for _, entry := range env {
+		set[envKey(entry)] = true
+	}
+
+	dotenv, err := LoadDotenv(filepath.Join(p.Root, ".env"))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range dotenv {
+		if key := envKey(entry); !set[key] {
+			set[key] = true
+			env = append(env, entry)
+		}
+	}
+
+	if !set["FLASK_APP"] {
+		env = append(env, "FLASK_APP="+p.AppFile())
+	}
+	return env, nil
+}
+
+// ファイルが存在するか
+func (p *Project) exists(name string) bool {
+	_, err := os.Stat(filepath.Join(p.Root, name))
+	return err == nil
+}
@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// run コマンド: プロジェクトの仮想環境と .env を使って flask run を実行する
+//
+//	flasgo run [--dir DIR] [--host HOST] [--port PORT] [--debug] [--venv PATH]
+func Run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	host := fs.String("host", "", "待ち受けるホスト (例: 0.0.0.0)")
+	port := fs.Int("port", 0, "待ち受けるポート（省略時は flask run のデフォルト）")
+	debug := fs.Bool("debug", false, "デバッグモード（自動リロード・デバッガ）で起動する")
+	venvPath := fs.String("venv", "", "仮想環境のパス（省略時は venv/ または .venv/）")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("引数が多すぎます: %s", strings.Join(fs.Args(), " "))
+	}
+	if *port < 0 || *port > 65535 {
+		return fmt.Errorf("不正なポート番号: %d", *port)
+	}
+
+	project, err := Load(*dir)
+	if err != nil {
+		return err
+	}
+	project.Venv = *venvPath
+
+	flaskArgs := []string{"run"}
+	if *host != "" {
+		flaskArgs = append(flaskArgs, "--host", *host)
+	}
+	if *port != 0 {
+		flaskArgs = append(flaskArgs, "--port", strconv.Itoa(*port))
+	}
+	if *debug {
+		flaskArgs = append([]string{"--debug"}, flaskArgs...)
+	}
+
+	cmd, err := project.Flask(flaskArgs...)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🚀 %s を起動します (FLASK_APP=%s)\n", project.Root, flaskApp(cmd.Env))
+	return Exec(cmd)
+}
+
+// 実行時の FLASK_APP の値
+func flaskApp(env []string) string {
+	for _, entry := range env {
+		if key, value, _ := strings.Cut(entry, "="); key == "FLASK_APP" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,377 @@
+package scaffold
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/cli"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/runner"
+)
+
+// フィールドの型と SQLAlchemy のカラム型
+var columnTypes = map[string]string{
+	"string":   "db.String(255)",
+	"text":     "db.Text",
+	"integer":  "db.Integer",
+	"float":    "db.Float",
+	"boolean":  "db.Boolean",
+	"date":     "db.Date",
+	"datetime": "db.DateTime",
+}
+
+// フィールドの型の一覧（エラー表示用）
+const fieldTypeNames = "string, string(N), text, integer, float, boolean, date, datetime, belongs_to:<Model>"
+
+// 関連（他のモデルへの外部キー）を表す型
+const belongsTo = "belongs_to"
+
+// to_dict で isoformat() に変換する型
+var isoformatTypes = map[string]bool{"date": true, "datetime": true}
+
+var (
+	// string(120) のような長さ指定付きの文字列型
+	sizedStringPattern = regexp.MustCompile(`^string\((\d+)\)$`)
+	// フィールド名・モデル名に使える文字
+	fieldNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+	modelNamePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// モデルのフィールド（name:type[:modifier...]）
+type field struct {
+	Name     string
+	Type     string // columnTypes のキーか belongsTo
+	Column   string // SQLAlchemy のカラム型
+	Target   string // belongs_to の関連先のモデル
+	Required bool
+	Unique   bool
+	Index    bool
+}
+
+// モデルのテンプレート（generators/model.py.tmpl）に渡すデータ
+type modelData struct {
+	Header        bool // モデルだけのファイルを新しく作る場合は import を含める
+	Name          string
+	Columns       []definition
+	Relationships []definition
+	Repr          string     // __repr__ に表示するフィールド
+	Dict          []dictItem // to_dict の項目（id 以外）
+}
+
+// 属性名と定義（例: title = db.Column(db.String(255), nullable=False)）
+type definition struct {
+	Name       string
+	Definition string
+}
+
+// to_dict の項目
+type dictItem struct {
+	Key   string
+	Value string
+}
+
+// make:model コマンド: SQLAlchemy のモデルを生成する
+//
+//	flasgo make:model <Model> [field:type[:required][:unique][:index]...] [--dir DIR]
+//	flasgo make:model Article title:string:required body:text author:belongs_to:User
+func Model(args []string) error {
+	fs := flag.NewFlagSet("make:model", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("モデル名を指定してください (例: flasgo make:model Article title:string:required)")
+	}
+
+	name := naming.ClassName(positional[0])
+	if !modelNamePattern.MatchString(name) || naming.IsKeyword(name) {
+		return fmt.Errorf("不正なモデル名: %s", positional[0])
+	}
+	fields, err := parseFields(positional[1:])
+	if err != nil {
+		return err
+	}
+
+	project, err := runner.Load(*dir)
+	if err != nil {
+		return err
+	}
+	if err := project.RequireFeature("database"); err != nil {
+		return err
+	}
+	target, err := modelTarget(project, name)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.Type == belongsTo && f.Target != name && !modelDefined(project, f.Target) {
+			fmt.Printf("⚠️  関連先のモデル '%s' が見つかりません（先に作成してください）\n", f.Target)
+		}
+	}
+
+	data := newModelData(name, fields)
+	data.Header = target.create
+	code, err := render(project, "model.py.tmpl", data)
+	if err != nil {
+		return err
+	}
+
+	if target.create {
+		if err := os.WriteFile(target.path, []byte(code), 0644); err != nil {
+			return fmt.Errorf("%s の書き込みに失敗: %v", relPath(project, target.path), err)
+		}
+	} else if err := insertCode(target.path, code, target.blank); err != nil {
+		return err
+	}
+	if target.packageInit != "" {
+		module := strings.TrimSuffix(filepath.Base(target.path), ".py")
+		if err := appendLine(target.packageInit, fmt.Sprintf("from .%s import %s", module, name)); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("✅ モデル '%s' を %s に追加しました\n", name, relPath(project, target.path))
+	fmt.Printf("\n次のステップ:\n")
+	fmt.Printf("  flasgo db migrate -m \"add %s\"\n", naming.SnakeCase(name))
+	fmt.Printf("  flasgo db upgrade\n")
+	return nil
+}
+
+// フィールドの指定を解析する
+func parseFields(specs []string) ([]field, error) {
+	var fields []field
+	names := map[string]bool{"id": true}
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("フィールド '%s' の型を指定してください (name:type、型は %s)", spec, fieldTypeNames)
+		}
+
+		f := field{Name: parts[0], Type: parts[1]}
+		if !fieldNamePattern.MatchString(f.Name) || naming.IsKeyword(f.Name) {
+			return nil, fmt.Errorf("不正なフィールド名: %s", f.Name)
+		}
+
+		modifiers := parts[2:]
+		switch {
+		case f.Type == belongsTo:
+			if len(modifiers) == 0 {
+				return nil, fmt.Errorf("フィールド '%s' の関連先のモデルを指定してください (例: %s:belongs_to:User)", f.Name, f.Name)
+			}
+			f.Target = naming.ClassName(modifiers[0])
+			if !modelNamePattern.MatchString(f.Target) {
+				return nil, fmt.Errorf("不正なモデル名: %s", modifiers[0])
+			}
+			f.Column = "db.Integer"
+			modifiers = modifiers[1:]
+		case sizedStringPattern.MatchString(f.Type):
+			size, _ := strconv.Atoi(sizedStringPattern.FindStringSubmatch(f.Type)[1])
+			f.Column = fmt.Sprintf("db.String(%d)", size)
+			f.Type = "string"
+		default:
+			column, ok := columnTypes[f.Type]
+			if !ok {
+				return nil, fmt.Errorf("フィールド '%s' の型 '%s' は使えません（%s から選択してください）", f.Name, f.Type, fieldTypeNames)
+			}
+			f.Column = column
+		}
+
+		for _, modifier := range modifiers {
+			switch modifier {
+			case "required":
+				f.Required = true
+			case "unique":
+				f.Unique = true
+			case "index":
+				f.Index = true
+			default:
+				return nil, fmt.Errorf("フィールド '%s' の修飾子 '%s' は使えません (required, unique, index)", f.Name, modifier)
+			}
+		}
+
+		for _, attr := range f.attributes() {
+			if names[attr] {
+				return nil, fmt.Errorf("フィールド '%s' が重複しています", attr)
+			}
+			names[attr] = true
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// フィールドがモデルに定義する属性名（belongs_to は外部キーと関連の2つ）
+func (f field) attributes() []string {
+	if f.Type == belongsTo {
+		return []string{f.Name + "_id", f.Name}
+	}
+	return []string{f.Name}
+}
+
+// テンプレートに渡すデータを作る
+func newModelData(name string, fields []field) *modelData {
+	data := &modelData{Name: name, Repr: "id"}
+
+	// 同じモデルへの関連が複数ある場合は外部キーと逆参照の名前を区別する
+	targets := make(map[string]int)
+	for _, f := range fields {
+		if f.Type == belongsTo {
+			targets[f.Target]++
+		}
+	}
+
+	for _, f := range fields {
+		column := f.Column
+		key := f.Name
+		if f.Type == belongsTo {
+			column += fmt.Sprintf(", db.ForeignKey('%s.id')", naming.SnakeCase(f.Target))
+			key = f.Name + "_id"
+		}
+		if f.Required {
+			column += ", nullable=False"
+		}
+		if f.Unique {
+			column += ", unique=True"
+		}
+		if f.Index {
+			column += ", index=True"
+		}
+		data.Columns = append(data.Columns, definition{key, fmt.Sprintf("db.Column(%s)", column)})
+
+		value := "self." + key
+		if isoformatTypes[f.Type] {
+			value = fmt.Sprintf("self.%s.isoformat() if self.%s else None", key, key)
+		}
+		data.Dict = append(data.Dict, dictItem{key, value})
+
+		if f.Type == belongsTo {
+			backref := pluralize(naming.SnakeCase(name))
+			options := ""
+			if targets[f.Target] > 1 {
+				backref += "_as_" + f.Name
+				options = fmt.Sprintf(", foreign_keys=[%s_id]", f.Name)
+			}
+			if f.Target == name { // 自己参照（親子関係など）
+				options += ", remote_side=[id]"
+			}
+			relationship := fmt.Sprintf("db.relationship('%s'%s, backref=db.backref('%s', lazy=True))", f.Target, options, backref)
+			data.Relationships = append(data.Relationships, definition{f.Name, relationship})
+		}
+
+		if data.Repr == "id" && f.Type == "string" {
+			data.Repr = f.Name
+		}
+	}
+	return data
+}
+
+// 英単語の複数形（逆参照の名前に使う簡易的な変換）
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+// モデルの追加先
+type modelFile struct {
+	path        string // 追加先のファイル
+	create      bool   // 新しくファイルを作る（models パッケージの場合）
+	blank       int    // 既存のコードとの間の空行の数
+	packageInit string // モデルを import する __init__.py（models パッケージの場合）
+}
+
+// モデルを追加するファイルを決める
+// シンプル・標準構造は models.py があればそこに、なければ app.py に追加する
+// Blueprint構造は app/models/ パッケージがあればその中に新しいファイルを作り、なければ app/models.py に追加する
+func modelTarget(project *runner.Project, name string) (*modelFile, error) {
+	var target *modelFile
+	if project.Structure == "blueprint" {
+		pkg := filepath.Join(project.Root, "app", "models")
+		if exists(filepath.Join(pkg, "__init__.py")) {
+			target = &modelFile{
+				path:        filepath.Join(pkg, naming.SnakeCase(name)+".py"),
+				create:      true,
+				packageInit: filepath.Join(pkg, "__init__.py"),
+			}
+		} else {
+			target = &modelFile{path: filepath.Join(project.Root, "app", "models.py"), blank: 2}
+		}
+	} else if path := filepath.Join(project.Root, "models.py"); exists(path) {
+		target = &modelFile{path: path, blank: 2}
+	} else {
+		target = &modelFile{path: filepath.Join(project.Root, "app.py"), blank: 1}
+	}
+
+	if target.create {
+		if exists(target.path) {
+			return nil, fmt.Errorf("%s は既に存在します", relPath(project, target.path))
+		}
+		return target, nil
+	}
+
+	content, err := os.ReadFile(target.path)
+	if err != nil {
+		return nil, fmt.Errorf("モデルの追加先 %s が見つかりません", relPath(project, target.path))
+	}
+	if !dbPattern.Match(content) {
+		return nil, fmt.Errorf("%s で db (SQLAlchemy) が定義・import されていません", relPath(project, target.path))
+	}
+	if classPattern(name).Match(content) {
+		return nil, fmt.Errorf("モデル '%s' は %s に既に定義されています", name, relPath(project, target.path))
+	}
+	return target, nil
+}
+
+// db = SQLAlchemy(...) の定義、または db の import
+var dbPattern = regexp.MustCompile(`(?m)^db = SQLAlchemy\(|^from \S+ import (.*\b)?db\b`)
+
+// プロジェクトにモデルが定義されているか（モデルの追加先になるファイルを探す）
+func modelDefined(project *runner.Project, name string) bool {
+	paths := []string{
+		filepath.Join(project.Root, "app.py"),
+		filepath.Join(project.Root, "models.py"),
+		filepath.Join(project.Root, "app", "models.py"),
+	}
+	if matches, err := filepath.Glob(filepath.Join(project.Root, "app", "models", "*.py")); err == nil {
+		paths = append(paths, matches...)
+	}
+	for _, path := range paths {
+		if definesClass(path, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ファイルの末尾に1行追加する
+func appendLine(path, line string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s の読み込みに失敗: %v", filepath.Base(path), err)
+	}
+	text := string(content)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if err := os.WriteFile(path, []byte(text+line+"\n"), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗: %v", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/KOU050223/flasgo/internal/runner"
+	"github.com/KOU050223/flasgo/internal/templates"
+)
+
+// コード生成に使うテンプレートのディレクトリ（プロジェクトには出力されない）
+const generatorsDir = "generators"
+
+// generators/ のテンプレートを描画する
+// プロジェクトがテンプレートパックで作成されていれば、パックのテンプレートを優先する
+// （パックがこの環境にない場合は警告を表示して組み込みのテンプレートを使う）
+func render(project *runner.Project, name string, data interface{}) (string, error) {
+	var packDir string
+	if project.Config != nil {
+		var err error
+		if packDir, err = templates.FindPack(project.Config.Pack, project.Config.TemplateDir); err != nil {
+			fmt.Printf("⚠️  %v（組み込みのテンプレートを使います）\n", err)
+		}
+	}
+
+	path := generatorsDir + "/" + name
+	src, tree, err := templates.ReadFile(templates.Trees(packDir), path)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(tree.Path(path)).Option("missingkey=error").Parse(src)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ファイルが存在するか
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Python のクラス定義を探すパターン
+func classPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^class ` + regexp.QuoteMeta(name) + `\b`)
+}
+
+// ファイルにクラスが定義されているか
+func definesClass(path, name string) bool {
+	content, err := os.ReadFile(path)
+	return err == nil && classPattern(name).Match(content)
+}
+
+var (
+	// "if __name__ == '__main__':" の行
+	mainGuardPattern = regexp.MustCompile(`(?m)^if __name__ == ['"]__main__['"]:`)
+	// モデル（db.Model を継承したクラス）の定義の行
+	modelClassPattern = regexp.MustCompile(`(?m)^class \w+\(.*\bdb\.Model\b.*\):`)
+	// インデントされていない行（クラス定義の終わりを探すために使う）
+	topLevelPattern = regexp.MustCompile(`(?m)^\S`)
+)
+
+// コードを追加する位置
+// 既存のモデルがあれば最後のモデルの後、なければ "if __name__ == '__main__':" の前、どちらもなければ末尾
+func insertionPoint(content string) int {
+	if locs := modelClassPattern.FindAllStringIndex(content, -1); len(locs) > 0 {
+		last := locs[len(locs)-1]
+		if next := topLevelPattern.FindStringIndex(content[last[1]:]); next != nil {
+			return last[1] + next[0]
+		}
+		return len(content)
+	}
+	if loc := mainGuardPattern.FindStringIndex(content); loc != nil {
+		return loc[0]
+	}
+	return len(content)
+}
+
+// コードを既存のファイルに追加する（前後の既存のコードとの間に空行を blank 行挟む）
+func insertCode(path, code string, blank int) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s の読み込みに失敗: %v", filepath.Base(path), err)
+	}
+
+	separator := strings.Repeat("\n", blank+1)
+	at := insertionPoint(string(content))
+	before, after := string(content[:at]), string(content[at:])
+
+	updated := strings.TrimRight(before, "\n") + separator + strings.TrimRight(code, "\n") + "\n"
+	if after != "" {
+		updated += separator[1:] + after
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗: %v", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// プロジェクトルートからの相対パス（表示用）
+func relPath(project *runner.Project, path string) string {
+	rel, err := filepath.Rel(project.Root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,174 @@
+package scaffold
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/cli"
+	"github.com/KOU050223/flasgo/internal/naming"
+	"github.com/KOU050223/flasgo/internal/runner"
+	"github.com/KOU050223/flasgo/internal/templates"
+)
+
+const (
+	// シーダーを置くパッケージ（プロジェクトルートからの相対パス）
+	seedersDir = "seeders"
+	// シーダーのパッケージのテンプレート（データベース機能のレイヤー内）
+	seedersTemplateDir = "features/database/seeders/seeders"
+)
+
+// シーダーのパッケージのファイル（__init__.py は fixtures.py の flask load も登録する）
+var seedersFiles = []string{"__init__.py", "fixtures.py"}
+
+// シーダーのテンプレート（generators/seeder.py.tmpl）に渡すデータ
+type seederData struct {
+	Name        string // シーダー名（例: Users）
+	Module      string // モジュール名（例: users）
+	Model       string // --truncate で削除するモデル（なければ空文字）
+	ModelModule string // モデルを import するモジュール
+}
+
+// SEEDERS = [ ... ] の閉じ括弧の行
+var seedersEndPattern = regexp.MustCompile(`(?m)^SEEDERS = \[[^\]]*?(\n?\])`)
+
+// make:seeder コマンド: シーダーのモジュールを作成して実行順に登録する
+//
+//	flasgo make:seeder <Name> [--model MODEL] [--dir DIR]
+func Seeder(args []string) error {
+	fs := flag.NewFlagSet("make:seeder", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	model := fs.String("model", "", "--truncate で削除するモデル（省略時はシーダー名の単数形のモデルがあれば使う）")
+
+	positional, err := cli.ParseArgs(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("シーダー名を1つ指定してください (例: flasgo make:seeder Users)")
+	}
+
+	name := naming.ClassName(positional[0])
+	module := naming.SnakeCase(name)
+	if !modelNamePattern.MatchString(name) || naming.IsKeyword(module) {
+		return fmt.Errorf("不正なシーダー名: %s", positional[0])
+	}
+
+	project, err := runner.Load(*dir)
+	if err != nil {
+		return err
+	}
+	if err := project.RequireFeature("database"); err != nil {
+		return err
+	}
+
+	data := &seederData{Name: name, Module: module, ModelModule: "app"}
+	if project.Structure == "blueprint" {
+		data.ModelModule = "app.models"
+	}
+	switch {
+	case *model != "":
+		data.Model = naming.ClassName(*model)
+		if !modelDefined(project, data.Model) {
+			fmt.Printf("⚠️  モデル '%s' が見つかりません\n", data.Model)
+		}
+	case modelDefined(project, singularize(name)):
+		data.Model = singularize(name)
+	}
+
+	pkg := filepath.Join(project.Root, seedersDir)
+	path := filepath.Join(pkg, module+".py")
+	if exists(path) {
+		return fmt.Errorf("%s は既に存在します", relPath(project, path))
+	}
+	if err := ensureSeedersPackage(project); err != nil {
+		return err
+	}
+
+	code, err := render(project, "seeder.py.tmpl", data)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗: %v", relPath(project, path), err)
+	}
+	if err := registerSeeder(filepath.Join(pkg, "__init__.py"), module); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ シーダー '%s' を %s に作成しました\n", name, relPath(project, path))
+	fmt.Printf("\n次のステップ:\n")
+	fmt.Printf("  %s の run() にデータを追加\n", relPath(project, path))
+	fmt.Printf("  flasgo db:seed %s\n", module)
+	return nil
+}
+
+// シーダーのパッケージがなければ作成する
+// （シーダーに対応する前のバージョンで作成したプロジェクトでは flask seed の登録方法を表示する）
+func ensureSeedersPackage(project *runner.Project) error {
+	init := filepath.Join(project.Root, seedersDir, "__init__.py")
+	if exists(init) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(init), 0755); err != nil {
+		return fmt.Errorf("%s/ の作成に失敗: %v", seedersDir, err)
+	}
+	for _, name := range seedersFiles {
+		content, _, err := templates.ReadFile(templates.Trees(""), seedersTemplateDir+"/"+name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(filepath.Dir(init), name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("%s の書き込みに失敗: %v", relPath(project, path), err)
+		}
+	}
+
+	fmt.Printf("📁 %s/ を作成しました。flask seed・flask load を使うにはアプリに以下を追加してください:\n", seedersDir)
+	fmt.Printf("    from seeders import register_seed_command\n")
+	fmt.Printf("    register_seed_command(app)\n\n")
+	return nil
+}
+
+// SEEDERS の末尾にシーダーを追加する
+func registerSeeder(init, module string) error {
+	content, err := os.ReadFile(init)
+	if err != nil {
+		return fmt.Errorf("%s の読み込みに失敗: %v", seedersDir+"/__init__.py", err)
+	}
+	text := string(content)
+
+	loc := seedersEndPattern.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return fmt.Errorf("%s/__init__.py に SEEDERS が見つかりません。'%s' を手動で追加してください", seedersDir, module)
+	}
+	end := loc[2] // 閉じ括弧（直前の改行を含む）の位置
+	entry := fmt.Sprintf("\n    '%s',", module)
+	text = text[:end] + entry + text[end:]
+
+	if err := os.WriteFile(init, []byte(text), 0644); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗: %v", seedersDir+"/__init__.py", err)
+	}
+	return nil
+}
+
+// 英単語の単数形（シーダー名からモデル名を推測する簡易的な変換）
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,47 @@
+from flask import render_template, redirect, url_for, flash, request
+from flask_login import login_user, logout_user, current_user
+
+from app import db
+from app.auth import bp
+from app.auth.forms import LoginForm, RegisterForm
+from app.models import User
+
+
+@bp.route('/register', methods=['GET', 'POST'])
+def register():
+    if current_user.is_authenticated:
+        return redirect(url_for('main.index'))
+    form = RegisterForm()
+    if form.validate_on_submit():
+        user = User(name=form.username.data)
+        user.set_password(form.password.data)
+        db.session.add(user)
+        db.session.commit()
+        flash('登録が完了しました。ログインしてください。', 'success')
+        return redirect(url_for('auth.login'))
+    return render_template('auth/register.html', form=form)
+
+
+@bp.route('/login', methods=['GET', 'POST'])
+def login():
+    if current_user.is_authenticated:
+        return redirect(url_for('main.index'))
+    form = LoginForm()
+    if form.validate_on_submit():
+        user = User.query.filter_by(name=form.username.data).first()
+        if user is None or not user.check_password(form.password.data):
+            flash('ユーザー名またはパスワードが正しくありません', 'error')
+            return redirect(url_for('auth.login'))
+        login_user(user, remember=form.remember_me.data)
+        next_page = request.args.get('next')
+        if not next_page or not next_page.startswith('/') or next_page.startswith('//'):
+            next_page = url_for('main.index')
+        return redirect(next_page)
+    return render_template('auth/login.html', form=form)
+
+
+@bp.route('/logout')
+def logout():
+    logout_user()
+    flash('ログアウトしました')
+    return redirect(url_for('main.index'))
@@ -0,0 +1,58 @@
+"""シーダー
+
+`flasgo make:seeder <Name>` で seeders/ にモジュールを追加し、
+`flask seed [NAME...]`（または `flasgo db:seed`）で実行する。
+CSV・JSON・YAML のファイルは `flask load FILE --model MODEL`（または `flasgo db:load`）で投入できる。
+"""
+import importlib
+
+import click
+from flask import current_app
+
+from .fixtures import load_command
+
+# 実行順に並べたシーダー（seeders/ 以下のモジュール名、flasgo make:seeder で追加される）
+SEEDERS = [
+]
+
+
+def first_or_create(model, defaults=None, **filters):
+    """filters に一致する行を返し、なければ作成する（シーダーを何度実行しても重複しないようにする）"""
+    db = current_app.extensions['sqlalchemy']
+    instance = db.session.query(model).filter_by(**filters).first()
+    if instance is None:
+        instance = model(**filters, **(defaults or {}))
+        db.session.add(instance)
+    return instance
+
+
+def register_seed_command(app):
+    """flask seed・flask load コマンドを登録する"""
+    app.cli.add_command(load_command)
+
+    @app.cli.command('seed')
+    @click.argument('names', nargs=-1)
+    @click.option('--truncate', is_flag=True, help='投入前に各シーダーの MODEL のデータを削除する')
+    def seed(names, truncate):
+        """シーダーでデータを投入する（NAME を省略すると全て実行）"""
+        unknown = [name for name in names if name not in SEEDERS]
+        if unknown:
+            raise click.BadParameter(f"不明なシーダー: {', '.join(unknown)}（{', '.join(SEEDERS) or 'なし'}）")
+
+        selected = [name for name in SEEDERS if not names or name in names]
+        modules = [importlib.import_module(f'{__name__}.{name}') for name in selected]
+        db = current_app.extensions['sqlalchemy']
+        try:
+            if truncate:
+                # 外部キーの参照先より先に参照元を削除するため逆順に削除する
+                for module in reversed(modules):
+                    if getattr(module, 'MODEL', None) is not None:
+                        db.session.query(module.MODEL).delete()
+            for name, module in zip(selected, modules):
+                module.run()
+                db.session.flush()
+                click.echo(f'seeded: {name}')
+            db.session.commit()
+        except Exception:
+            db.session.rollback()
+            raise
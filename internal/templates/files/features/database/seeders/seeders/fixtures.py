@@ -0,0 +1,221 @@
+"""フィクスチャ（CSV・JSON・YAML のデータファイル）の投入
+
+`flask load FILE --model MODEL`（または `flasgo db:load`）でファイルの各行をモデルの行として投入する。
+列名（JSON・YAML ではキー）はモデルのカラム名に対応させる。
+1行でもエラーがあれば何も投入せず、エラーのあった行をまとめて表示する。
+"""
+import csv
+import datetime
+import decimal
+import json
+import os
+
+import click
+import sqlalchemy as sa
+from flask import current_app
+
+# 真偽値として受け付ける文字列
+TRUE_VALUES = {'true', 't', 'yes', 'y', '1'}
+FALSE_VALUES = {'false', 'f', 'no', 'n', '0'}
+
+
+class FixtureError(Exception):
+    """フィクスチャのファイル全体に関するエラー"""
+
+
+def read_records(path):
+    """ファイルを読み込み、(行の表示名, 辞書) の一覧を返す"""
+    ext = os.path.splitext(path)[1].lower()
+    if ext == '.csv':
+        with open(path, newline='', encoding='utf-8-sig') as f:
+            reader = csv.DictReader(f)
+            records = [(f'{reader.line_num}行目', row) for row in reader]
+        if reader.fieldnames is None:
+            raise FixtureError('見出し行がありません')
+        return records
+
+    if ext == '.json':
+        with open(path, encoding='utf-8') as f:
+            try:
+                data = json.load(f)
+            except json.JSONDecodeError as e:
+                raise FixtureError(f'JSON の解析に失敗: {e}')
+    elif ext in ('.yaml', '.yml'):
+        try:
+            import yaml
+        except ImportError:
+            raise FixtureError('YAML を読み込むには PyYAML をインストールしてください (pip install PyYAML)')
+        with open(path, encoding='utf-8') as f:
+            try:
+                data = yaml.safe_load(f)
+            except yaml.YAMLError as e:
+                raise FixtureError(f'YAML の解析に失敗: {e}')
+    else:
+        raise FixtureError(f'対応していない形式です: {ext or path}（.csv, .json, .yaml, .yml）')
+
+    if not isinstance(data, list):
+        raise FixtureError('データはオブジェクトのリストで記述してください')
+    for i, record in enumerate(data, 1):
+        if not isinstance(record, dict):
+            raise FixtureError(f'{i}件目: オブジェクトではありません')
+    return [(f'{i}件目', record) for i, record in enumerate(data, 1)]
+
+
+def find_model(name):
+    """アプリに登録されているモデルをクラス名で探す"""
+    db = current_app.extensions['sqlalchemy']
+    models = sorted(mapper.class_.__name__ for mapper in db.Model.registry.mappers)
+    for mapper in db.Model.registry.mappers:
+        if mapper.class_.__name__ == name:
+            return mapper.class_
+    raise FixtureError(f"モデル '{name}' が見つかりません（{', '.join(models) or 'なし'}）。"
+                       'アプリから import されているか確認してください')
+
+
+def is_required(column):
+    """値の指定が必須のカラムか（NOT NULL で、既定値も自動採番もないもの）"""
+    if column.nullable or column.default is not None or column.server_default is not None:
+        return False
+    return not (column.primary_key and column.autoincrement in (True, 'auto'))
+
+
+def convert(column, value):
+    """ファイルの値をカラムの型に変換する（変換できなければ ValueError）"""
+    if value is None or value == '':
+        if is_required(column):
+            raise ValueError('必須です')
+        return None
+    try:
+        python_type = column.type.python_type
+    except NotImplementedError:
+        return value
+
+    if python_type is bool:
+        if isinstance(value, bool):
+            return value
+        text = str(value).strip().lower()
+        if text in TRUE_VALUES:
+            return True
+        if text in FALSE_VALUES:
+            return False
+        raise ValueError(f'真偽値ではありません ({value!r})')
+
+    if python_type is int:
+        if isinstance(value, bool) or (isinstance(value, float) and not value.is_integer()):
+            raise ValueError(f'整数ではありません ({value!r})')
+        try:
+            return int(value)
+        except ValueError:
+            raise ValueError(f'整数ではありません ({value!r})')
+
+    if python_type in (float, decimal.Decimal):
+        if isinstance(value, bool):
+            raise ValueError(f'数値ではありません ({value!r})')
+        try:
+            return python_type(str(value).strip())
+        except (ValueError, decimal.InvalidOperation):
+            raise ValueError(f'数値ではありません ({value!r})')
+
+    # YAML は日付・日時を変換済みで返す（datetime は date のサブクラスなので先に判定する）
+    if python_type is datetime.datetime:
+        if isinstance(value, datetime.datetime):
+            return value
+        try:
+            return datetime.datetime.fromisoformat(str(value).strip())
+        except ValueError:
+            raise ValueError(f'日時ではありません ({value!r}、例: 2024-01-31T09:00:00)')
+
+    if python_type is datetime.date:
+        if isinstance(value, datetime.date) and not isinstance(value, datetime.datetime):
+            return value
+        try:
+            return datetime.date.fromisoformat(str(value).strip())
+        except ValueError:
+            raise ValueError(f'日付ではありません ({value!r}、例: 2024-01-31)')
+
+    if python_type is str:
+        value = str(value)
+        length = getattr(column.type, 'length', None)
+        if length is not None and len(value) > length:
+            raise ValueError(f'{length} 文字を超えています ({len(value)} 文字)')
+        return value
+
+    return value
+
+
+def load_fixture(model, records):
+    """records を検証してモデルの行を追加し、(追加した件数, エラーの一覧) を返す
+
+    エラーがあった場合は呼び出し側でロールバックする。
+    """
+    db = current_app.extensions['sqlalchemy']
+    columns = {column.key: column for column in sa.inspect(model).columns}
+
+    # モデルにない列はファイル全体のエラーにする
+    keys = {key for _, record in records for key in record}
+    if None in keys:
+        rows = [label for label, record in records if None in record]
+        raise FixtureError(f"見出しより列が多い行があります: {', '.join(rows)}")
+    unknown = sorted(keys - columns.keys())
+    if unknown:
+        raise FixtureError(f"モデル {model.__name__} にないカラム: {', '.join(unknown)}"
+                           f"（{', '.join(columns)}）")
+
+    errors = []
+    count = 0
+    for label, record in records:
+        values = {}
+        messages = []
+        for key, column in columns.items():
+            try:
+                value = convert(column, record.get(key))
+            except ValueError as e:
+                messages.append(f'{key}: {e}')
+                continue
+            if value is not None:
+                values[key] = value
+        if messages:
+            errors.append((label, '、'.join(messages)))
+            continue
+        if errors:
+            continue  # 投入しないので検証だけ続ける
+
+        db.session.add(model(**values))
+        try:
+            db.session.flush()
+        except sa.exc.IntegrityError as e:
+            # 一意制約・外部キーなどの違反（以降の行はセッションを使えないので打ち切る）
+            errors.append((label, str(e.orig)))
+            break
+        count += 1
+    return count, errors
+
+
+@click.command('load')
+@click.argument('path', type=click.Path(exists=True, dir_okay=False))
+@click.option('--model', 'model_name', required=True, help='投入先のモデル（例: User）')
+@click.option('--truncate', is_flag=True, help='投入前にモデルのデータを削除する')
+def load_command(path, model_name, truncate):
+    """CSV・JSON・YAML のファイルからモデルにデータを投入する"""
+    db = current_app.extensions['sqlalchemy']
+    try:
+        model = find_model(model_name)
+        records = read_records(path)
+        if truncate:
+            db.session.query(model).delete()
+        count, errors = load_fixture(model, records)
+    except FixtureError as e:
+        db.session.rollback()
+        raise click.ClickException(f'{os.path.basename(path)}: {e}')
+    except Exception:
+        db.session.rollback()
+        raise
+
+    if errors:
+        db.session.rollback()
+        for label, message in errors:
+            click.echo(f'  {label}: {message}', err=True)
+        raise click.ClickException(f'{os.path.basename(path)}: {len(errors)} 件のエラーがあるため投入しませんでした')
+
+    db.session.commit()
+    click.echo(f'loaded: {count} {model.__name__} ({os.path.basename(path)})')
@@ -0,0 +1,88 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// テンプレートパック
+//
+// 組み込みテンプレート（files/）と同じレイアウトのディレクトリで、
+// 同じパスのファイルは組み込みのものを置き換え、それ以外のファイルは追加される。
+// 新しいレイヤーを追加する場合はパックの layers.yaml に記述する。
+
+// テンプレートパックを置くディレクトリ（$XDG_CONFIG_HOME/flasgo/templates または ~/.config/flasgo/templates）
+func PackRoot() (string, error) {
+	if config := os.Getenv("XDG_CONFIG_HOME"); config != "" {
+		return filepath.Join(config, "flasgo", "templates"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("ホームディレクトリの取得に失敗: %v", err)
+	}
+	return filepath.Join(home, ".config", "flasgo", "templates"), nil
+}
+
+// テンプレートパックのディレクトリを求める
+// name を指定した場合は dir（省略時は PackRoot）配下の name を、
+// dir だけを指定した場合は dir そのものをパックとして使う（どちらも空なら空文字）
+func FindPack(name, dir string) (string, error) {
+	if name == "" && dir == "" {
+		return "", nil
+	}
+
+	path := dir
+	if name != "" {
+		if filepath.Base(name) != name || name == "." || name == ".." {
+			return "", fmt.Errorf("不正なテンプレートパック名: %s", name)
+		}
+		root := dir
+		if root == "" {
+			var err error
+			if root, err = PackRoot(); err != nil {
+				return "", err
+			}
+		}
+		path = filepath.Join(root, name)
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		if name != "" {
+			return "", fmt.Errorf("テンプレートパック '%s' が見つかりません (%s)", name, path)
+		}
+		return "", fmt.Errorf("テンプレートディレクトリが見つかりません: %s", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("テンプレートパックの確認に失敗: %v", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("'%s' はディレクトリではありません", path)
+	}
+	return path, nil
+}
+
+// テンプレートのツリー
+type Tree struct {
+	FS   fs.FS
+	Root string // エラー表示用のディレクトリ（組み込みテンプレートは空文字）
+}
+
+// ツリー内のファイルの表示用パス
+func (t Tree) Path(name string) string {
+	if t.Root == "" {
+		return name
+	}
+	return filepath.Join(t.Root, filepath.FromSlash(name))
+}
+
+// 組み込みテンプレートとパックのツリーを適用順に返す（後のツリーが優先される）
+func Trees(packDir string) []Tree {
+	trees := []Tree{{FS: Files()}}
+	if packDir != "" {
+		trees = append(trees, Tree{FS: os.DirFS(packDir), Root: packDir})
+	}
+	return trees
+}
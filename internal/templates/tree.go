@@ -0,0 +1,133 @@
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 組み込みテンプレート（files/ 以下が生成されるプロジェクトのレイアウトに対応する）
+//
+//go:embed all:files
+var embedded embed.FS
+
+const (
+	// レイヤー定義ファイル
+	LayersFile = "layers.yaml"
+	// 共通部品（{{define}} で定義したテンプレート）を置くディレクトリ
+	PartialsDir = "partials"
+	// Go テンプレートとして処理するファイルの拡張子
+	TemplateExt = ".tmpl"
+	// 出力時に "." に置き換えるファイル名の接頭辞
+	dotPrefix = "dot_"
+)
+
+// 機能ごとの部品（{{slot}} で差し込む "<機能>.<名前>" の {{define}}）を置くディレクトリ
+func FeaturePartialsDir(id string) string {
+	return path.Join("features", id, PartialsDir)
+}
+
+// テンプレートのレイヤー
+// When が真になる場合に Dir 以下のファイルがプロジェクトに追加される
+type Layer struct {
+	Dir  string `yaml:"dir"`
+	When string `yaml:"when"`
+}
+
+// 組み込みテンプレートのファイルツリー
+func Files() fs.FS {
+	sub, err := fs.Sub(embedded, "files")
+	if err != nil {
+		panic(err) // 埋め込み時に存在が保証されている
+	}
+	return sub
+}
+
+// レイヤー定義を読み込む
+// 最初のツリー（組み込みテンプレート）の定義をベースに、後のツリーの定義で
+// 同じ dir のレイヤーの条件を置き換え、新しい dir のレイヤーを末尾に追加する
+// （後のツリーの layers.yaml は省略できる）
+func Layers(trees ...Tree) ([]Layer, error) {
+	var layers []Layer
+	index := make(map[string]int) // dir → layers の位置
+	for i, tree := range trees {
+		data, err := fs.ReadFile(tree.FS, LayersFile)
+		if i > 0 && errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("レイヤー定義の読み込みに失敗: %v", err)
+		}
+
+		var defined []Layer
+		if err := yaml.Unmarshal(data, &defined); err != nil {
+			return nil, fmt.Errorf("レイヤー定義の解析に失敗 (%s): %v", tree.Path(LayersFile), err)
+		}
+		for _, layer := range defined {
+			if layer.Dir == "" {
+				return nil, fmt.Errorf("レイヤー定義に dir がありません (%s)", tree.Path(LayersFile))
+			}
+			if j, ok := index[layer.Dir]; ok {
+				layers[j] = layer
+				continue
+			}
+			index[layer.Dir] = len(layers)
+			layers = append(layers, layer)
+		}
+	}
+	return layers, nil
+}
+
+// テンプレートファイルかどうか
+func IsTemplate(name string) bool {
+	return strings.HasSuffix(name, TemplateExt)
+}
+
+// Jinja のテンプレートとして出力するファイルの拡張子
+// Jinja の {{ }} と衝突しないように [[ ]] を区切り文字にして処理する
+var jinjaExts = []string{".html", ".jinja", ".j2"}
+
+// テンプレートの区切り文字（Jinja のテンプレートは [[ ]]、それ以外は {{ }}）
+func Delims(name string) (left, right string) {
+	ext := path.Ext(strings.TrimSuffix(name, TemplateExt))
+	for _, jinja := range jinjaExts {
+		if ext == jinja {
+			return "[[", "]]"
+		}
+	}
+	return "{{", "}}"
+}
+
+// テンプレートツリー内のパスから出力先のパスを求める
+// （拡張子 .tmpl を除き、各要素の先頭の "dot_" を "." に置き換える）
+func OutputPath(name string) string {
+	name = strings.TrimSuffix(name, TemplateExt)
+
+	elems := strings.Split(name, "/")
+	for i, elem := range elems {
+		if strings.HasPrefix(elem, dotPrefix) {
+			elems[i] = "." + strings.TrimPrefix(elem, dotPrefix)
+		}
+	}
+	return path.Join(elems...)
+}
+
+// ツリーからファイルを読み込む（後のツリーにあるものを優先する）
+func ReadFile(trees []Tree, name string) (string, Tree, error) {
+	for i := len(trees) - 1; i >= 0; i-- {
+		content, err := fs.ReadFile(trees[i].FS, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", trees[i], fmt.Errorf("テンプレートの読み込みに失敗 (%s): %v", trees[i].Path(name), err)
+		}
+		return string(content), trees[i], nil
+	}
+	return "", Tree{}, fmt.Errorf("テンプレートが見つかりません: %s", name)
+}
@@ -0,0 +1,13 @@
+package templates
+
+// テンプレートセットのバージョン
+// 生成される内容を変更したら上げる（プロジェクトのマニフェストに記録される）
+// 追加機能のテンプレート（features/<機能>/ や機能ごとの記述）は機能の ID をキーにする
+var Versions = map[string]string{
+	"flask":     "12",
+	"blueprint": "8",
+	"database":  "3",
+	"auth":      "3",
+	"forms":     "2",
+	"env":       "2",
+}
@@ -8,30 +8,44 @@ import (
 	"strings"
 )
 
+// 標準入力のリーダー（プロンプト間でバッファを共有する）
+var stdin = bufio.NewReader(os.Stdin)
+
 // プロンプト用の構造体
 type Option struct {
 	Label string
 	Value string
 }
 
-// テキスト入力を求める
-func PromptText(question string, defaultValue string) string {
-	reader := bufio.NewReader(os.Stdin)
-	
+// 検証を通る値が入力されるまでテキスト入力を求める
+// 入力が終了した場合（EOF）は検証に失敗した値でもそのまま返す
+func PromptValidText(question string, defaultValue string, validate func(string) error) string {
+	for {
+		input, err := readText(question, defaultValue)
+		validateErr := validate(input)
+		if validateErr == nil || err != nil {
+			return input
+		}
+		fmt.Printf("  ❌ %v\n", validateErr)
+	}
+}
+
+// 質問を表示して1行読み込む（空入力ならデフォルト値）
+func readText(question string, defaultValue string) (string, error) {
 	if defaultValue != "" {
 		fmt.Printf("? %s (%s): ", question, defaultValue)
 	} else {
 		fmt.Printf("? %s: ", question)
 	}
-	
-	input, _ := reader.ReadString('\n')
+
+	input, err := stdin.ReadString('\n')
 	input = strings.TrimSpace(input)
-	
+
 	if input == "" && defaultValue != "" {
-		return defaultValue
+		return defaultValue, err
 	}
-	
-	return input
+
+	return input, err
 }
 
 // 選択肢から選ぶ
@@ -46,11 +60,16 @@ func PromptSelect(question string, options []Option) string {
 		}
 	}
 	
-	reader := bufio.NewReader(os.Stdin)
 	for {
 		fmt.Print("選択してください (1-" + strconv.Itoa(len(options)) + "): ")
-		input, _ := reader.ReadString('\n')
+		input, err := stdin.ReadString('\n')
 		input = strings.TrimSpace(input)
+
+		// 入力が終了した場合（パイプ等）は先頭の選択肢を既定値として返す
+		if err != nil && input == "" {
+			fmt.Println()
+			return options[0].Value
+		}
 		
 		if num, err := strconv.Atoi(input); err == nil {
 			if num >= 1 && num <= len(options) {
@@ -70,9 +89,8 @@ func PromptMultiSelect(question string, options []Option) []string {
 		fmt.Printf("    %d) %s\n", i+1, option.Label)
 	}
 	
-	reader := bufio.NewReader(os.Stdin)
 	fmt.Print("選択してください: ")
-	input, _ := reader.ReadString('\n')
+	input, _ := stdin.ReadString('\n')
 	input = strings.TrimSpace(input)
 	
 	if input == "" {
@@ -95,10 +113,9 @@ func PromptMultiSelect(question string, options []Option) []string {
 
 // 確認プロンプト
 func PromptConfirm(question string) bool {
-	reader := bufio.NewReader(os.Stdin)
 	fmt.Printf("? %s (y/N): ", question)
 	
-	input, _ := reader.ReadString('\n')
+	input, _ := stdin.ReadString('\n')
 	input = strings.TrimSpace(strings.ToLower(input))
 	
 	return input == "y" || input == "yes"
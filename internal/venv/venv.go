@@ -0,0 +1,218 @@
+package venv
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/KOU050223/flasgo/internal/manifest"
+	"github.com/KOU050223/flasgo/internal/python"
+)
+
+// 仮想環境のデフォルトの作成先（プロジェクトルートからの相対パス）
+const DefaultPath = "venv"
+
+// 仮想環境作成のオプション
+type Options struct {
+	Dir       string // プロジェクトのディレクトリ
+	Path      string // 仮想環境の作成先（相対パスは Dir からの相対）
+	FindLinks string // パッケージを探すローカルのディレクトリ（指定時は PyPI を使わずにインストールする）
+	Python    string // Python の最小バージョン（省略時はプロジェクトの .python-version）
+}
+
+// venv コマンド: プロジェクトの仮想環境を作成して依存関係をインストールする
+//
+//	flasgo venv [--dir DIR] [--path PATH] [--find-links DIR] [--python 3.X]
+func Run(args []string) error {
+	fs := flag.NewFlagSet("venv", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "プロジェクトのディレクトリ")
+	path := fs.String("path", DefaultPath, "仮想環境の作成先（プロジェクトからの相対パス）")
+	findLinks := fs.String("find-links", "", "wheel を置いたディレクトリからオフラインでインストールする")
+	pin := fs.String("python", "", "使用する Python の最小バージョン（省略時は .python-version）")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("引数が多すぎます: %s", strings.Join(fs.Args(), " "))
+	}
+
+	return Setup(Options{Dir: *dir, Path: *path, FindLinks: *findLinks, Python: *pin})
+}
+
+// 仮想環境を自身で管理する管理ツールと、その依存関係のインストール手順
+var managedTools = map[string]string{
+	"poetry": "poetry install",
+	"uv":     "uv sync",
+}
+
+// 管理ツールが flasgo で仮想環境を作成できるものか調べる
+func Supports(packaging string) error {
+	if command, ok := managedTools[packaging]; ok {
+		return fmt.Errorf("%s は仮想環境を自身で管理します。代わりに '%s' を実行してください", packaging, command)
+	}
+	return nil
+}
+
+// 仮想環境を作成（既にあれば再利用）し、pip の更新と依存関係のインストールを行う
+func Setup(opts Options) error {
+	root, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return fmt.Errorf("プロジェクトディレクトリの解決に失敗: %v", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("プロジェクトディレクトリが見つかりません: %s", opts.Dir)
+	}
+
+	packaging, err := projectPackaging(root)
+	if err != nil {
+		return err
+	}
+	if err := Supports(packaging); err != nil {
+		return err
+	}
+
+	pin := opts.Python
+	if pin == "" {
+		if pin, err = python.ReadVersionFile(root); err != nil {
+			return err
+		}
+	}
+	if err := python.ValidatePin(pin); err != nil {
+		return err
+	}
+	minimum := python.Minimum(pin)
+
+	// --find-links 指定時は PyPI を参照せずローカルの wheel だけを使う
+	var index []string
+	if opts.FindLinks != "" {
+		wheelhouse, err := filepath.Abs(opts.FindLinks)
+		if err != nil {
+			return fmt.Errorf("wheel ディレクトリの解決に失敗: %v", err)
+		}
+		if info, err := os.Stat(wheelhouse); err != nil || !info.IsDir() {
+			return fmt.Errorf("wheel ディレクトリが見つかりません: %s", opts.FindLinks)
+		}
+		index = []string{"--no-index", "--find-links", wheelhouse}
+	}
+
+	path := opts.Path
+	if path == "" {
+		path = DefaultPath
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+
+	executable := Python(path)
+	if _, err := os.Stat(executable); err == nil {
+		existing, err := python.Inspect(executable)
+		if err != nil {
+			return err
+		}
+		if python.Compare(existing.Version, minimum) < 0 {
+			return fmt.Errorf("既存の仮想環境の Python %s は %s 未満です。%s を削除してから作り直してください", existing.Version, minimum, path)
+		}
+		fmt.Printf("📂 既存の仮想環境を使用します: %s (Python %s)\n", path, existing.Version)
+	} else {
+		interpreter, err := python.Find(minimum)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🐍 仮想環境を作成中: %s (Python %s: %s)\n", path, interpreter.Version, interpreter.Path)
+		if err := run(root, interpreter.Path, "-m", "venv", path); err != nil {
+			return fmt.Errorf("仮想環境の作成に失敗: %v", err)
+		}
+	}
+
+	fmt.Printf("📦 pip を更新中...\n")
+	if err := pip(root, executable, index, "install", "--upgrade", "pip"); err != nil {
+		return fmt.Errorf("pip の更新に失敗: %v", err)
+	}
+
+	if err := installDependencies(root, executable, packaging, index); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 仮想環境を準備しました: %s\n", path)
+	return nil
+}
+
+// 依存関係をインストールする
+// pip-tools のプロジェクトで requirements.txt がなければ pyproject.toml から生成する
+func installDependencies(root, executable, packaging string, index []string) error {
+	requirements := filepath.Join(root, "requirements.txt")
+	if _, err := os.Stat(requirements); os.IsNotExist(err) && packaging == "pip-tools" {
+		fmt.Printf("📦 pip-tools で requirements.txt を生成中...\n")
+		if err := pip(root, executable, index, "install", "pip-tools"); err != nil {
+			return fmt.Errorf("pip-tools のインストールに失敗: %v", err)
+		}
+		compile := append([]string{"-m", "piptools", "compile", "-o", "requirements.txt"}, index...)
+		if err := run(root, executable, append(compile, "pyproject.toml")...); err != nil {
+			return fmt.Errorf("requirements.txt の生成に失敗: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(requirements); os.IsNotExist(err) {
+		fmt.Printf("⚠️  requirements.txt がないため依存関係のインストールをスキップしました\n")
+		return nil
+	}
+
+	fmt.Printf("📦 依存関係をインストール中...\n")
+	if err := pip(root, executable, index, "install", "-r", "requirements.txt"); err != nil {
+		return fmt.Errorf("依存関係のインストールに失敗: %v", err)
+	}
+	return nil
+}
+
+// プロジェクトの依存関係の管理ツール（マニフェストがなければ空文字）
+func projectPackaging(root string) (string, error) {
+	if _, err := os.Stat(filepath.Join(root, manifest.FileName)); os.IsNotExist(err) {
+		return "", nil
+	}
+	m, err := manifest.Load(root)
+	if err != nil {
+		return "", err
+	}
+	return m.Config.Packaging, nil
+}
+
+// 仮想環境内の Python のパス
+func Python(path string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(path, "Scripts", "python.exe")
+	}
+	return filepath.Join(path, "bin", "python")
+}
+
+// 仮想環境を有効化するコマンド（表示用）
+func ActivateCommand(path string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(path, "Scripts", "activate")
+	}
+	return "source " + filepath.Join(path, "bin", "activate")
+}
+
+// 仮想環境の pip を実行する
+func pip(root, executable string, index []string, args ...string) error {
+	command := append([]string{"-m", "pip"}, args...)
+	return run(root, executable, append(command, index...)...)
+}
+
+// コマンドを実行する（出力はそのまま表示する）
+func run(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
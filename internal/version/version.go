@@ -0,0 +1,5 @@
+package version
+
+// flasgo のバージョン
+// リリースビルドでは -ldflags "-X github.com/KOU050223/flasgo/internal/version.Version=..." で上書きする
+var Version = "0.1.0"
@@ -1,12 +1,18 @@
 package types
 
 // プロジェクト設定
+// 回答ファイル (--from / --save-answers) の読み書きにも使用する
 type ProjectConfig struct {
-	Name      string   // プロジェクト名
-	Type      string   // アプリタイプ (hello, webapp, api, fullstack)
-	Structure string   // プロジェクト構造 (simple, standard, blueprint)
-	Features  []string // 追加機能 (database, auth, forms, env)
-	Path      string   // 作成先パス
+	Name      string   `yaml:"name" json:"name"`                               // プロジェクト名
+	Type      string   `yaml:"type" json:"type"`                               // アプリタイプ (hello, webapp, api, fullstack)
+	Structure string   `yaml:"structure" json:"structure"`                     // プロジェクト構造 (simple, standard, blueprint)
+	Features  []string `yaml:"features" json:"features"`                       // 追加機能 (internal/features に登録された機能の ID)
+	Packaging string   `yaml:"packaging,omitempty" json:"packaging,omitempty"` // 依存関係の管理ツール (pip, pip-tools, poetry, uv)
+	Path      string   `yaml:"path,omitempty" json:"path,omitempty"`           // 作成先パス
+	Python    string   `yaml:"python,omitempty" json:"python,omitempty"`       // Python の最小バージョン（.python-version に記録する）
+
+	Pack        string `yaml:"pack,omitempty" json:"pack,omitempty"`                 // テンプレートパック名
+	TemplateDir string `yaml:"template_dir,omitempty" json:"template_dir,omitempty"` // テンプレートパックの検索先（Pack を省略した場合はパックそのもの、マニフェストにはプロジェクトからの相対パスで記録）
 }
 
 // アプリタイプの定義
@@ -30,13 +36,13 @@ var ProjectStructures = []struct {
 	{"blueprint", "Blueprint構造 (大規模プロジェクト向け)"},
 }
 
-// 追加機能の定義
-var AdditionalFeatures = []struct {
+// 依存関係の管理ツールの定義
+var PackagingTools = []struct {
 	Value string
 	Label string
 }{
-	{"database", "データベース (SQLAlchemy)"},
-	{"auth", "認証機能 (Flask-Login)"},
-	{"forms", "フォーム処理 (Flask-WTF)"},
-	{"env", "環境変数管理 (.env)"},
-}
\ No newline at end of file
+	{"pip", "pip (requirements.txt)"},
+	{"pip-tools", "pip-tools (pyproject.toml + pip-compile)"},
+	{"poetry", "Poetry (pyproject.toml)"},
+	{"uv", "uv (pyproject.toml)"},
+}